@@ -0,0 +1,237 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by a CircuitBreaker while it is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every call fast with ErrCircuitOpen.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial call through to probe the messenger.
+	BreakerHalfOpen
+)
+
+// String returns the lower case name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("state(%d)", int(s))
+	}
+}
+
+// CircuitBreaker is a messenger that stops calling a failing messenger.
+// After threshold consecutive failures it opens and fails fast with ErrCircuitOpen.
+// Once the cooldown has elapsed it becomes half-open and lets a single trial call through:
+// a success closes it, a failure opens it again.
+// Combined with a Fallback, traffic shifts to the next messenger while the circuit is open.
+type CircuitBreaker struct {
+	next          Messenger
+	now           func() time.Time
+	isFailure     func(err error) bool
+	onStateChange func(from, to BreakerState)
+	openedAt      time.Time
+	cooldown      time.Duration
+	threshold     int
+	failures      int
+	generation    uint64
+	state         BreakerState
+	trial         bool
+	mu            sync.Mutex
+}
+
+type BreakerOption func(*CircuitBreaker)
+
+// NewCircuitBreaker wraps the messenger with a circuit breaker.
+// By default it opens after 5 consecutive failures and probes again after 30s.
+func NewCircuitBreaker(m Messenger, options ...BreakerOption) *CircuitBreaker {
+	breaker := &CircuitBreaker{
+		next:      m,
+		now:       time.Now,
+		isFailure: isBreakerFailure,
+		cooldown:  defaultCooldown,
+		threshold: defaultFailureThreshold,
+	}
+
+	for _, opt := range options {
+		opt(breaker)
+	}
+
+	return breaker
+}
+
+// WithFailureThreshold sets how many consecutive failures open the circuit.
+func WithFailureThreshold(threshold int) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.threshold = max(threshold, 1)
+	}
+}
+
+// WithCooldown sets how long the circuit stays open before a trial call is let through.
+func WithCooldown(cooldown time.Duration) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.cooldown = cooldown
+	}
+}
+
+// WithFailureClassifier sets which errors count as failures.
+// By default every error counts except context cancellation.
+func WithFailureClassifier(isFailure func(err error) bool) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.isFailure = isFailure
+	}
+}
+
+// WithStateChange sets a function called on every state change,
+// e.g. to export the state as a metric.
+// It is called with the lock of the breaker held and must not call the breaker.
+func WithStateChange(onStateChange func(from, to BreakerState)) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onStateChange = onStateChange
+	}
+}
+
+func isBreakerFailure(err error) bool {
+	return !errors.Is(err, context.Canceled)
+}
+
+// Name returns the name of the wrapped messenger.
+func (b *CircuitBreaker) Name() string {
+	return NameOf(b.next)
+}
+
+// State returns the current state of the circuit, e.g. for health checks.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	return b.state
+}
+
+// Send sends the configured message of the wrapped messenger unless the circuit is open.
+func (b *CircuitBreaker) Send(ctx context.Context) error {
+	return b.call(ctx, b.next.Send)
+}
+
+// Notify delivers the notification through the wrapped messenger unless the circuit is open.
+func (b *CircuitBreaker) Notify(ctx context.Context, n Notification) error {
+	return b.call(ctx, func(ctx context.Context) error {
+		return b.next.Notify(ctx, n)
+	})
+}
+
+func (b *CircuitBreaker) call(ctx context.Context, send func(context.Context) error) error {
+	generation, trial, err := b.acquire()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b.release(generation, trial, fmt.Errorf("panic recovered: %v", r))
+			panic(r)
+		}
+	}()
+
+	err = send(ctx)
+	b.release(generation, trial, err)
+
+	return err
+}
+
+// acquire checks whether a call can go through
+// and whether it is the trial call of a half-open circuit.
+// It returns the generation of the state the call was admitted in.
+func (b *CircuitBreaker) acquire() (uint64, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case BreakerOpen:
+		return 0, false, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.trial {
+			return 0, false, ErrCircuitOpen
+		}
+		b.trial = true
+		return b.generation, true, nil
+	default:
+		return b.generation, false, nil
+	}
+}
+
+// release records the outcome of a call.
+// Errors that are not failures leave the circuit unchanged, and so do the outcomes
+// of calls admitted before the last state change, e.g. a slow call that succeeds
+// after concurrent failures opened the circuit must not close it.
+func (b *CircuitBreaker) release(generation uint64, trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if trial {
+		b.trial = false
+	}
+	if generation != b.generation {
+		return
+	}
+
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.transition(BreakerClosed)
+	case b.isFailure(err):
+		b.failures++
+		if trial || b.failures >= b.threshold {
+			b.openedAt = b.now()
+			b.transition(BreakerOpen)
+		}
+	}
+}
+
+// refresh moves an open circuit to half-open once the cooldown has elapsed.
+// It must be called with the lock held.
+func (b *CircuitBreaker) refresh() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.transition(BreakerHalfOpen)
+	}
+}
+
+// transition changes the state of the circuit.
+// It must be called with the lock held.
+func (b *CircuitBreaker) transition(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	b.generation++
+	if b.onStateChange != nil {
+		b.onStateChange(from, state)
+	}
+}
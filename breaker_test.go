@@ -0,0 +1,225 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func failingMessenger(calls *int) *MockMessenger {
+	return &MockMessenger{
+		name: "resend",
+		sendFunc: func(_ context.Context) error {
+			*calls++
+			return errors.New("service unavailable")
+		},
+	}
+}
+
+func TestBreakerStateString(t *testing.T) {
+	t.Run("should return the name of the state", func(t *testing.T) {
+		assert.AreEqual(t, BreakerClosed.String(), "closed")
+		assert.AreEqual(t, BreakerOpen.String(), "open")
+		assert.AreEqual(t, BreakerHalfOpen.String(), "half-open")
+		assert.AreEqual(t, BreakerState(42).String(), "state(42)")
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("should open after the failure threshold and fail fast", func(t *testing.T) {
+		calls := 0
+		breaker := NewCircuitBreaker(failingMessenger(&calls), WithFailureThreshold(2))
+
+		_ = breaker.Send(context.Background())
+		assert.AreEqual(t, breaker.State(), BreakerClosed)
+		_ = breaker.Send(context.Background())
+		err := breaker.Send(context.Background())
+
+		assert.AreEqual(t, breaker.State(), BreakerOpen)
+		assert.AreEqual(t, errors.Is(err, ErrCircuitOpen), true)
+		assert.AreEqual(t, calls, 2, "Expected the messenger not to be called while open")
+	})
+
+	t.Run("should reset the failures after a success", func(t *testing.T) {
+		fail := true
+		breaker := NewCircuitBreaker(&MockMessenger{sendFunc: func(_ context.Context) error {
+			if fail {
+				return errors.New("service unavailable")
+			}
+			return nil
+		}}, WithFailureThreshold(2))
+
+		_ = breaker.Send(context.Background())
+		fail = false
+		_ = breaker.Send(context.Background())
+		fail = true
+		_ = breaker.Send(context.Background())
+
+		assert.AreEqual(t, breaker.State(), BreakerClosed)
+	})
+
+	t.Run("should close after a successful trial once the cooldown elapsed", func(t *testing.T) {
+		fail := true
+		var transitions []string
+		clock := &fakeClock{}
+		breaker := NewCircuitBreaker(
+			&MockMessenger{notifyFunc: func(_ context.Context, _ Notification) error {
+				if fail {
+					return errors.New("service unavailable")
+				}
+				return nil
+			}},
+			WithFailureThreshold(1),
+			WithCooldown(time.Minute),
+			WithStateChange(func(from, to BreakerState) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			}),
+		)
+		breaker.now = clock.Now
+		_ = breaker.Notify(context.Background(), Notification{Title: "Disk full"})
+
+		clock.now = clock.now.Add(time.Minute)
+		assert.AreEqual(t, breaker.State(), BreakerHalfOpen)
+		fail = false
+		err := breaker.Notify(context.Background(), Notification{Title: "Disk full"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, breaker.State(), BreakerClosed)
+		assert.AreEqual(t, transitions, []string{"closed->open", "open->half-open", "half-open->closed"})
+	})
+
+	t.Run("should open again after a failed trial", func(t *testing.T) {
+		calls := 0
+		clock := &fakeClock{}
+		breaker := NewCircuitBreaker(
+			failingMessenger(&calls),
+			WithFailureThreshold(3),
+			WithCooldown(time.Minute),
+		)
+		breaker.now = clock.Now
+		for range 3 {
+			_ = breaker.Send(context.Background())
+		}
+
+		clock.now = clock.now.Add(time.Minute)
+		_ = breaker.Send(context.Background())
+
+		assert.AreEqual(t, breaker.State(), BreakerOpen)
+		assert.AreEqual(t, calls, 4)
+	})
+
+	t.Run("should let a single trial call through while half-open", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		clock := &fakeClock{}
+		breaker := NewCircuitBreaker(
+			&MockMessenger{sendFunc: func(_ context.Context) error {
+				close(started)
+				<-release
+				return nil
+			}},
+			WithCooldown(time.Minute),
+		)
+		breaker.now = clock.Now
+		breaker.state = BreakerOpen
+		clock.now = clock.now.Add(time.Minute)
+		done := make(chan error)
+
+		go func() { done <- breaker.Send(context.Background()) }()
+		<-started
+		err := breaker.Send(context.Background())
+		close(release)
+
+		assert.AreEqual(t, errors.Is(err, ErrCircuitOpen), true)
+		assert.IsNil(t, <-done)
+	})
+
+	t.Run("should ignore calls admitted before the circuit opened", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		breaker := NewCircuitBreaker(&MockMessenger{
+			sendFunc: func(_ context.Context) error {
+				close(started)
+				<-release
+				return nil
+			},
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				return errors.New("service unavailable")
+			},
+		}, WithFailureThreshold(2))
+		done := make(chan error)
+
+		go func() { done <- breaker.Send(context.Background()) }()
+		<-started
+		_ = breaker.Notify(context.Background(), Notification{Title: "Disk full"})
+		_ = breaker.Notify(context.Background(), Notification{Title: "Disk full"})
+		close(release)
+
+		assert.IsNil(t, <-done)
+		assert.AreEqual(t, breaker.State(), BreakerOpen, "Expected the slow success not to close it")
+	})
+
+	t.Run("should not count canceled calls as failures", func(t *testing.T) {
+		errUnavailable := errors.New("service unavailable")
+		errs := []error{errUnavailable, context.Canceled, errUnavailable}
+		breaker := NewCircuitBreaker(&MockMessenger{sendFunc: func(_ context.Context) error {
+			err := errs[0]
+			errs = errs[1:]
+			return err
+		}}, WithFailureThreshold(2))
+
+		_ = breaker.Send(context.Background())
+		_ = breaker.Send(context.Background())
+		assert.AreEqual(t, breaker.State(), BreakerClosed)
+		_ = breaker.Send(context.Background())
+
+		assert.AreEqual(t, breaker.State(), BreakerOpen, "Expected canceled call not to reset failures")
+	})
+
+	t.Run("should use the failure classifier", func(t *testing.T) {
+		errInvalid := errors.New("invalid recipient")
+		breaker := NewCircuitBreaker(
+			&MockMessenger{sendFunc: func(_ context.Context) error { return errInvalid }},
+			WithFailureThreshold(1),
+			WithFailureClassifier(func(err error) bool { return !errors.Is(err, errInvalid) }),
+		)
+
+		_ = breaker.Send(context.Background())
+
+		assert.AreEqual(t, breaker.State(), BreakerClosed)
+	})
+
+	t.Run("should count panics as failures", func(t *testing.T) {
+		breaker := NewCircuitBreaker(&MockMessenger{sendFunc: func(_ context.Context) error {
+			panic("unexpected panic")
+		}}, WithFailureThreshold(1))
+
+		err := NewWithMessengers(breaker).SendAll(context.Background())
+
+		assert.IsNotNil(t, err)
+		assert.AreEqual(t, breaker.State(), BreakerOpen)
+	})
+
+	t.Run("should shift traffic to the next messenger of a fallback", func(t *testing.T) {
+		calls := 0
+		breaker := NewCircuitBreaker(failingMessenger(&calls), WithFailureThreshold(1))
+		fallback := NewFallback(breaker, &MockMessenger{name: "slack"})
+
+		_ = fallback.Send(context.Background())
+		report, err := fallback.SendReport(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, report.Delivered, "slack")
+		assert.AreEqual(t, errors.Is(report.Errors[0], ErrCircuitOpen), true)
+		assert.AreEqual(t, calls, 1)
+	})
+
+	t.Run("should keep the name of the wrapped messenger", func(t *testing.T) {
+		breaker := NewCircuitBreaker(&MockMessenger{name: "resend"})
+
+		assert.AreEqual(t, NameOf(breaker), "resend")
+	})
+}
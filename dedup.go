@@ -0,0 +1,287 @@
+package nofy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDedupTTL = time.Minute
+	// sendFingerprint is the fingerprint of every Send, which has no notification.
+	sendFingerprint = "send"
+)
+
+// Fingerprint identifies duplicate notifications.
+// Notifications with the same fingerprint are duplicates.
+type Fingerprint func(n Notification) string
+
+// ContentFingerprint returns a hash of the whole content of the notification.
+func ContentFingerprint(n Notification) string {
+	data, err := json.Marshal(n)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%#v", n))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// MetadataFingerprint uses the metadata value of the key as an explicit fingerprint,
+// e.g. an alert ID. Notifications without the key fall back to ContentFingerprint.
+func MetadataFingerprint(key string) Fingerprint {
+	return func(n Notification) string {
+		if value, ok := n.Metadata[key]; ok {
+			return "metadata:" + value
+		}
+		return ContentFingerprint(n)
+	}
+}
+
+// Dedup is a messenger that suppresses duplicate notifications.
+// The first notification of a fingerprint is delivered and opens a suppression window;
+// duplicates are dropped until the window closes, the TTL after the delivery.
+// Duplicates arriving while the first notification is being delivered wait for it
+// and return its error, so a failed delivery is never reported as suppressed.
+// Suppressed notifications are not errors, they are counted by Suppressed.
+type Dedup struct {
+	next          Messenger
+	fingerprint   Fingerprint
+	afterFunc     func(d time.Duration, f func()) stopper
+	onSummaryErr  func(err error)
+	windows       map[string]*window
+	ttl           time.Duration
+	suppressed    int
+	repeatSummary bool
+	mu            sync.Mutex
+}
+
+type stopper interface {
+	Stop() bool
+}
+
+// window is the suppression window of a fingerprint.
+// notification is nil for Send.
+// done is closed once the first notification is delivered, with its error in err;
+// timer is nil until then.
+type window struct {
+	timer        stopper
+	err          error
+	notification *Notification
+	done         chan struct{}
+	suppressed   int
+}
+
+type DedupOption func(*Dedup)
+
+// NewDedup wraps the messenger with deduplication.
+// By default notifications are identified by ContentFingerprint and suppressed for a minute.
+func NewDedup(m Messenger, options ...DedupOption) *Dedup {
+	dedup := &Dedup{
+		next:        m,
+		fingerprint: ContentFingerprint,
+		afterFunc: func(d time.Duration, f func()) stopper {
+			return time.AfterFunc(d, f)
+		},
+		windows: make(map[string]*window),
+		ttl:     defaultDedupTTL,
+	}
+
+	for _, opt := range options {
+		opt(dedup)
+	}
+
+	return dedup
+}
+
+// WithDedupTTL sets how long duplicates are suppressed after a delivery.
+func WithDedupTTL(ttl time.Duration) DedupOption {
+	return func(d *Dedup) {
+		d.ttl = ttl
+	}
+}
+
+// WithFingerprint sets how duplicate notifications are identified.
+func WithFingerprint(fingerprint Fingerprint) DedupOption {
+	return func(d *Dedup) {
+		d.fingerprint = fingerprint
+	}
+}
+
+// WithRepeatSummary sends a "repeated N times" summary of the notification
+// when a window that suppressed duplicates closes.
+func WithRepeatSummary() DedupOption {
+	return func(d *Dedup) {
+		d.repeatSummary = true
+	}
+}
+
+// WithSummaryErrorHandler sets a function called when a summary sent
+// in the background fails to be delivered.
+func WithSummaryErrorHandler(onSummaryErr func(err error)) DedupOption {
+	return func(d *Dedup) {
+		d.onSummaryErr = onSummaryErr
+	}
+}
+
+// Name returns the name of the wrapped messenger.
+func (d *Dedup) Name() string {
+	return NameOf(d.next)
+}
+
+// Suppressed returns how many duplicates were suppressed so far.
+func (d *Dedup) Suppressed() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.suppressed
+}
+
+// Send sends the configured message of the wrapped messenger unless it was sent within the TTL.
+func (d *Dedup) Send(ctx context.Context) error {
+	return d.call(ctx, sendFingerprint, nil, d.next.Send)
+}
+
+// Notify delivers the notification through the wrapped messenger
+// unless a duplicate was delivered within the TTL.
+func (d *Dedup) Notify(ctx context.Context, n Notification) error {
+	return d.call(ctx, d.fingerprint(n), &n, func(ctx context.Context) error {
+		return d.next.Notify(ctx, n)
+	})
+}
+
+// Close closes every open window, sending their summaries,
+// and stops the background timers.
+func (d *Dedup) Close(ctx context.Context) error {
+	d.mu.Lock()
+	windows := d.windows
+	d.windows = make(map[string]*window)
+	d.mu.Unlock()
+
+	var errs []error
+	for _, w := range windows {
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+		if err := d.summarize(ctx, w); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// call delivers unless a window is open for the fingerprint.
+// The window is opened before delivering so concurrent duplicates wait for the delivery,
+// and closed again when the delivery fails so that it can be retried.
+func (d *Dedup) call(
+	ctx context.Context,
+	fingerprint string,
+	n *Notification,
+	send func(context.Context) error,
+) error {
+	d.mu.Lock()
+	if w, ok := d.windows[fingerprint]; ok {
+		d.mu.Unlock()
+		return d.suppress(ctx, w)
+	}
+
+	w := &window{notification: n, done: make(chan struct{})}
+	d.windows[fingerprint] = w
+	d.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			d.delivered(fingerprint, w, fmt.Errorf("panic recovered: %v", r))
+			panic(r)
+		}
+	}()
+
+	err := send(ctx)
+	d.delivered(fingerprint, w, err)
+
+	return err
+}
+
+// delivered records the outcome of the first notification of a window:
+// the TTL starts on success, the window is closed on failure.
+func (d *Dedup) delivered(fingerprint string, w *window, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w.err = err
+	close(w.done)
+
+	if d.windows[fingerprint] != w {
+		return
+	}
+	if err != nil {
+		delete(d.windows, fingerprint)
+		return
+	}
+	w.timer = d.afterFunc(d.ttl, func() {
+		d.expire(fingerprint, w)
+	})
+}
+
+// suppress waits for the first notification of the window to be delivered
+// and counts the duplicate as suppressed when the delivery succeeded.
+func (d *Dedup) suppress(ctx context.Context, w *window) error {
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	d.mu.Lock()
+	w.suppressed++
+	d.suppressed++
+	d.mu.Unlock()
+
+	return nil
+}
+
+// expire closes the window once its TTL elapsed and sends its summary.
+func (d *Dedup) expire(fingerprint string, w *window) {
+	d.mu.Lock()
+	if d.windows[fingerprint] != w {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.windows, fingerprint)
+	d.mu.Unlock()
+
+	err := d.summarize(context.Background(), w)
+	if err != nil && d.onSummaryErr != nil {
+		d.onSummaryErr(err)
+	}
+}
+
+// summarize sends the summary of a closed window when it suppressed duplicates.
+func (d *Dedup) summarize(ctx context.Context, w *window) error {
+	d.mu.Lock()
+	suppressed := w.suppressed
+	d.mu.Unlock()
+
+	if !d.repeatSummary || w.notification == nil || suppressed == 0 {
+		return nil
+	}
+
+	summary := *w.notification
+	if summary.Title == "" {
+		summary.Title = fmt.Sprintf("Repeated %d times", suppressed)
+	} else {
+		summary.Title = fmt.Sprintf("%s (repeated %d times)", summary.Title, suppressed)
+	}
+
+	if err := d.next.Notify(ctx, summary); err != nil {
+		return fmt.Errorf("error sending summary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func recordingMessenger(titles *[]string) *MockMessenger {
+	return &MockMessenger{
+		name: "slack",
+		notifyFunc: func(_ context.Context, n Notification) error {
+			*titles = append(*titles, n.Title)
+			return nil
+		},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Run("should hash the content of the notification", func(t *testing.T) {
+		first := ContentFingerprint(Notification{Title: "Health check failed"})
+		second := ContentFingerprint(Notification{Title: "Health check failed"})
+		other := ContentFingerprint(Notification{Title: "Health check failed", Body: "db"})
+
+		assert.AreEqual(t, first, second)
+		assert.AreEqual(t, first != other, true)
+	})
+
+	t.Run("should use the metadata value as an explicit key", func(t *testing.T) {
+		fingerprint := MetadataFingerprint("alert")
+
+		first := fingerprint(Notification{Title: "1", Metadata: map[string]string{"alert": "db"}})
+		second := fingerprint(Notification{Title: "2", Metadata: map[string]string{"alert": "db"}})
+		missing := fingerprint(Notification{Title: "1"})
+
+		assert.AreEqual(t, first, second)
+		assert.AreEqual(t, missing, ContentFingerprint(Notification{Title: "1"}))
+	})
+}
+
+func TestDedup(t *testing.T) {
+	t.Run("should suppress duplicates within the window", func(t *testing.T) {
+		var titles []string
+		dedup := NewDedup(recordingMessenger(&titles))
+		n := Notification{Title: "Health check failed"}
+
+		err := dedup.Notify(context.Background(), n)
+		duplicateErr := dedup.Notify(context.Background(), n)
+		otherErr := dedup.Notify(context.Background(), Notification{Title: "Disk full"})
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, duplicateErr)
+		assert.IsNil(t, otherErr)
+		assert.AreEqual(t, titles, []string{"Health check failed", "Disk full"})
+		assert.AreEqual(t, dedup.Suppressed(), 1)
+	})
+
+	t.Run("should deliver again once the window closed", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		dedup := NewDedup(recordingMessenger(&titles))
+		dedup.afterFunc = clock.AfterFunc
+		n := Notification{Title: "Health check failed"}
+
+		_ = dedup.Notify(context.Background(), n)
+		clock.timers[0].f()
+		_ = dedup.Notify(context.Background(), n)
+
+		assert.AreEqual(t, titles, []string{"Health check failed", "Health check failed"})
+	})
+
+	t.Run("should not open a window when the delivery failed", func(t *testing.T) {
+		calls := 0
+		clock := &fakeClock{}
+		dedup := NewDedup(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				calls++
+				return errors.New("service unavailable")
+			},
+		})
+		dedup.afterFunc = clock.AfterFunc
+		n := Notification{Title: "Health check failed"}
+
+		_ = dedup.Notify(context.Background(), n)
+		err := dedup.Notify(context.Background(), n)
+
+		assert.AreEqual(t, err.Error(), "service unavailable")
+		assert.AreEqual(t, calls, 2)
+		assert.AreEqual(t, len(clock.timers), 0)
+	})
+
+	t.Run("should return the error of the delivery in flight to duplicates", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{}, 2)
+		dedup := NewDedup(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				started <- struct{}{}
+				<-release
+				return errors.New("service unavailable")
+			},
+		})
+		n := Notification{Title: "Health check failed"}
+		done := make(chan error)
+
+		go func() { done <- dedup.Notify(context.Background(), n) }()
+		<-started
+		go func() { done <- dedup.Notify(context.Background(), n) }()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+
+		assert.AreEqual(t, (<-done).Error(), "service unavailable")
+		assert.AreEqual(t, (<-done).Error(), "service unavailable")
+		assert.AreEqual(t, dedup.Suppressed(), 0)
+	})
+
+	t.Run("should suppress duplicates once the delivery in flight succeeded", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{}, 2)
+		dedup := NewDedup(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			},
+		})
+		n := Notification{Title: "Health check failed"}
+		done := make(chan error)
+
+		go func() { done <- dedup.Notify(context.Background(), n) }()
+		<-started
+		go func() { done <- dedup.Notify(context.Background(), n) }()
+		time.Sleep(10 * time.Millisecond)
+		assert.AreEqual(t, dedup.Suppressed(), 0)
+		close(release)
+
+		assert.IsNil(t, <-done)
+		assert.IsNil(t, <-done)
+		assert.AreEqual(t, dedup.Suppressed(), 1)
+	})
+
+	t.Run("should send a summary when the window closes", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		dedup := NewDedup(recordingMessenger(&titles), WithRepeatSummary())
+		dedup.afterFunc = clock.AfterFunc
+		n := Notification{Title: "Health check failed"}
+
+		for range 3 {
+			_ = dedup.Notify(context.Background(), n)
+		}
+		clock.timers[0].f()
+
+		assert.AreEqual(t, titles, []string{
+			"Health check failed",
+			"Health check failed (repeated 2 times)",
+		})
+	})
+
+	t.Run("should not send a summary without duplicates", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		dedup := NewDedup(recordingMessenger(&titles), WithRepeatSummary())
+		dedup.afterFunc = clock.AfterFunc
+
+		_ = dedup.Notify(context.Background(), Notification{Title: "Health check failed"})
+		clock.timers[0].f()
+
+		assert.AreEqual(t, titles, []string{"Health check failed"})
+	})
+
+	t.Run("should report summary errors to the handler", func(t *testing.T) {
+		var summaryErr error
+		calls := 0
+		clock := &fakeClock{}
+		dedup := NewDedup(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				calls++
+				if calls > 1 {
+					return errors.New("service unavailable")
+				}
+				return nil
+			},
+		}, WithRepeatSummary(), WithSummaryErrorHandler(func(err error) {
+			summaryErr = err
+		}))
+		dedup.afterFunc = clock.AfterFunc
+		n := Notification{Title: "Health check failed"}
+
+		_ = dedup.Notify(context.Background(), n)
+		_ = dedup.Notify(context.Background(), n)
+		clock.timers[0].f()
+
+		assert.AreEqual(t, summaryErr.Error(), "error sending summary: service unavailable")
+	})
+
+	t.Run("should use the explicit fingerprint", func(t *testing.T) {
+		var titles []string
+		dedup := NewDedup(
+			recordingMessenger(&titles),
+			WithFingerprint(MetadataFingerprint("alert")),
+		)
+		metadata := map[string]string{"alert": "db"}
+
+		_ = dedup.Notify(context.Background(), Notification{Title: "1", Metadata: metadata})
+		_ = dedup.Notify(context.Background(), Notification{Title: "2", Metadata: metadata})
+
+		assert.AreEqual(t, titles, []string{"1"})
+	})
+
+	t.Run("should suppress repeated sends", func(t *testing.T) {
+		calls := 0
+		dedup := NewDedup(&MockMessenger{sendFunc: func(_ context.Context) error {
+			calls++
+			return nil
+		}})
+
+		_ = dedup.Send(context.Background())
+		_ = dedup.Send(context.Background())
+
+		assert.AreEqual(t, calls, 1)
+		assert.AreEqual(t, dedup.Suppressed(), 1)
+	})
+
+	t.Run("should send the summaries of open windows on close", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		dedup := NewDedup(recordingMessenger(&titles), WithRepeatSummary())
+		dedup.afterFunc = clock.AfterFunc
+		n := Notification{Title: "Health check failed"}
+		_ = dedup.Notify(context.Background(), n)
+		_ = dedup.Notify(context.Background(), n)
+
+		err := dedup.Close(context.Background())
+		clock.timers[0].f()
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, clock.timers[0].stopped, true)
+		assert.AreEqual(t, titles, []string{
+			"Health check failed",
+			"Health check failed (repeated 1 times)",
+		})
+	})
+
+	t.Run("should keep the name of the wrapped messenger", func(t *testing.T) {
+		dedup := NewDedup(&MockMessenger{name: "slack"})
+
+		assert.AreEqual(t, dedup.Name(), "slack")
+	})
+}
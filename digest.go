@@ -0,0 +1,236 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDigestWindow   = time.Minute
+	defaultDigestMaxItems = 20
+)
+
+// DigestNotifier is implemented by messengers that natively deliver
+// several notifications as a single message, e.g. one Slack message with
+// a group of blocks per notification.
+type DigestNotifier interface {
+	NotifyDigest(ctx context.Context, notifications []Notification) error
+}
+
+// Digest is a messenger that accumulates notifications and delivers them as a single digest,
+// once the window elapsed since the first one or once the max number of items is reached.
+// Notifications are grouped by key and each group is delivered as its own digest.
+// Digests are delivered with NotifyDigest when the wrapped messenger implements DigestNotifier
+// and as the notification returned by Combine otherwise.
+// Send is not batched and is delivered immediately.
+type Digest struct {
+	next       Messenger
+	key        func(n Notification) string
+	afterFunc  func(d time.Duration, f func()) stopper
+	onFlushErr func(err error)
+	batches    map[string]*batch
+	window     time.Duration
+	maxItems   int
+	closed     bool
+	mu         sync.Mutex
+}
+
+type batch struct {
+	timer         stopper
+	notifications []Notification
+}
+
+type DigestOption func(*Digest)
+
+// NewDigest wraps the messenger with batching.
+// By default a digest is delivered a minute after its first notification or after 20 notifications.
+func NewDigest(m Messenger, options ...DigestOption) *Digest {
+	digest := &Digest{
+		next: m,
+		key:  func(Notification) string { return "" },
+		afterFunc: func(d time.Duration, f func()) stopper {
+			return time.AfterFunc(d, f)
+		},
+		batches:  make(map[string]*batch),
+		window:   defaultDigestWindow,
+		maxItems: defaultDigestMaxItems,
+	}
+
+	for _, opt := range options {
+		opt(digest)
+	}
+
+	return digest
+}
+
+// WithDigestWindow sets how long notifications are accumulated before a digest is delivered.
+func WithDigestWindow(window time.Duration) DigestOption {
+	return func(d *Digest) {
+		d.window = window
+	}
+}
+
+// WithDigestMaxItems sets how many notifications trigger a digest before the window elapsed.
+func WithDigestMaxItems(maxItems int) DigestOption {
+	return func(d *Digest) {
+		d.maxItems = max(maxItems, 1)
+	}
+}
+
+// WithDigestKey groups notifications by key, e.g. by service or severity.
+func WithDigestKey(key func(n Notification) string) DigestOption {
+	return func(d *Digest) {
+		d.key = key
+	}
+}
+
+// WithFlushErrorHandler sets a function called when a digest delivered
+// in the background fails to be delivered.
+func WithFlushErrorHandler(onFlushErr func(err error)) DigestOption {
+	return func(d *Digest) {
+		d.onFlushErr = onFlushErr
+	}
+}
+
+// Name returns the name of the wrapped messenger.
+func (d *Digest) Name() string {
+	return NameOf(d.next)
+}
+
+// Send sends the configured message of the wrapped messenger immediately.
+func (d *Digest) Send(ctx context.Context) error {
+	return d.next.Send(ctx)
+}
+
+// Notify adds the notification to the digest of its key.
+// It only delivers, and returns the error of the delivery, when the digest is full.
+// After Close, notifications are delivered immediately.
+func (d *Digest) Notify(ctx context.Context, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	key := d.key(n)
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return d.next.Notify(ctx, n)
+	}
+
+	b, ok := d.batches[key]
+	if !ok {
+		b = &batch{}
+		b.timer = d.afterFunc(d.window, func() {
+			d.expire(key, b)
+		})
+		d.batches[key] = b
+	}
+	b.notifications = append(b.notifications, n)
+
+	if len(b.notifications) < d.maxItems {
+		d.mu.Unlock()
+		return nil
+	}
+
+	b.timer.Stop()
+	delete(d.batches, key)
+	d.mu.Unlock()
+
+	return d.deliver(ctx, b.notifications)
+}
+
+// Flush delivers every pending digest now.
+func (d *Digest) Flush(ctx context.Context) error {
+	d.mu.Lock()
+	batches := d.batches
+	d.batches = make(map[string]*batch)
+	d.mu.Unlock()
+
+	var errs []error
+	for _, b := range batches {
+		b.timer.Stop()
+		if err := d.deliver(ctx, b.notifications); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes the pending digests, e.g. on shutdown,
+// and delivers the following notifications immediately.
+func (d *Digest) Close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	return d.Flush(ctx)
+}
+
+// expire delivers the digest once its window elapsed.
+func (d *Digest) expire(key string, b *batch) {
+	d.mu.Lock()
+	if d.batches[key] != b {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.batches, key)
+	d.mu.Unlock()
+
+	err := d.deliver(context.Background(), b.notifications)
+	if err != nil && d.onFlushErr != nil {
+		d.onFlushErr(err)
+	}
+}
+
+// deliver delivers the notifications as a single digest.
+func (d *Digest) deliver(ctx context.Context, notifications []Notification) error {
+	if len(notifications) == 1 {
+		return d.next.Notify(ctx, notifications[0])
+	}
+
+	if digester, ok := d.next.(DigestNotifier); ok {
+		return digester.NotifyDigest(ctx, notifications)
+	}
+	return d.next.Notify(ctx, Combine(notifications))
+}
+
+// Combine merges notifications into a single one for messengers without native digests.
+// The title counts the notifications, the severity is the highest one,
+// the body lists the title and body of every notification,
+// and the fields, links and attachments are concatenated.
+// Only the metadata shared by every notification is kept.
+func Combine(notifications []Notification) Notification {
+	combined := Notification{
+		Title: fmt.Sprintf("%d notifications", len(notifications)),
+	}
+
+	sections := make([]string, 0, len(notifications))
+	for i, n := range notifications {
+		combined.Severity = max(combined.Severity, n.Severity)
+		combined.Fields = append(combined.Fields, n.Fields...)
+		combined.Links = append(combined.Links, n.Links...)
+		combined.Attachments = append(combined.Attachments, n.Attachments...)
+		sections = append(sections, strings.TrimSpace(n.Title+"\n"+n.Body))
+
+		if i == 0 {
+			combined.Metadata = make(map[string]string, len(n.Metadata))
+			for key, value := range n.Metadata {
+				combined.Metadata[key] = value
+			}
+			continue
+		}
+		for key, value := range combined.Metadata {
+			if other, ok := n.Metadata[key]; !ok || other != value {
+				delete(combined.Metadata, key)
+			}
+		}
+	}
+	combined.Body = strings.Join(sections, "\n\n")
+
+	return combined
+}
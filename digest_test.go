@@ -0,0 +1,196 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+// digestMessenger records the digests it receives natively.
+type digestMessenger struct {
+	MockMessenger
+	digests [][]Notification
+}
+
+func (m *digestMessenger) NotifyDigest(_ context.Context, notifications []Notification) error {
+	m.digests = append(m.digests, notifications)
+	return nil
+}
+
+func TestDigest(t *testing.T) {
+	t.Run("should deliver a digest when the window elapsed", func(t *testing.T) {
+		m := &digestMessenger{}
+		clock := &fakeClock{}
+		digest := NewDigest(m)
+		digest.afterFunc = clock.AfterFunc
+
+		err := digest.Notify(context.Background(), Notification{Title: "1"})
+		_ = digest.Notify(context.Background(), Notification{Title: "2"})
+		pending := len(m.digests)
+		clock.timers[0].f()
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, pending, 0)
+		assert.AreEqual(t, m.digests, [][]Notification{{{Title: "1"}, {Title: "2"}}})
+	})
+
+	t.Run("should deliver a digest when the max items is reached", func(t *testing.T) {
+		m := &digestMessenger{}
+		clock := &fakeClock{}
+		digest := NewDigest(m, WithDigestMaxItems(2))
+		digest.afterFunc = clock.AfterFunc
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1"})
+		_ = digest.Notify(context.Background(), Notification{Title: "2"})
+		_ = digest.Notify(context.Background(), Notification{Title: "3"})
+
+		assert.AreEqual(t, m.digests, [][]Notification{{{Title: "1"}, {Title: "2"}}})
+		assert.AreEqual(t, clock.timers[0].stopped, true)
+		assert.AreEqual(t, len(clock.timers), 2, "Expected a new window for the third notification")
+	})
+
+	t.Run("should return the error of a digest delivered when full", func(t *testing.T) {
+		digest := NewDigest(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				return errors.New("service unavailable")
+			},
+		}, WithDigestMaxItems(1))
+
+		err := digest.Notify(context.Background(), Notification{Title: "1"})
+
+		assert.AreEqual(t, err.Error(), "service unavailable")
+	})
+
+	t.Run("should deliver a single notification as is", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		digest := NewDigest(recordingMessenger(&titles))
+		digest.afterFunc = clock.AfterFunc
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1"})
+		clock.timers[0].f()
+
+		assert.AreEqual(t, titles, []string{"1"})
+	})
+
+	t.Run("should combine notifications without native digests", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		digest := NewDigest(recordingMessenger(&titles))
+		digest.afterFunc = clock.AfterFunc
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1"})
+		_ = digest.Notify(context.Background(), Notification{Title: "2"})
+		clock.timers[0].f()
+
+		assert.AreEqual(t, titles, []string{"2 notifications"})
+	})
+
+	t.Run("should group notifications by key", func(t *testing.T) {
+		m := &digestMessenger{}
+		clock := &fakeClock{}
+		digest := NewDigest(m, WithDigestKey(func(n Notification) string {
+			return n.Metadata["service"]
+		}))
+		digest.afterFunc = clock.AfterFunc
+		api := map[string]string{"service": "api"}
+		db := map[string]string{"service": "db"}
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1", Metadata: api})
+		_ = digest.Notify(context.Background(), Notification{Title: "2", Metadata: db})
+		_ = digest.Notify(context.Background(), Notification{Title: "3", Metadata: api})
+		clock.timers[0].f()
+
+		assert.AreEqual(t, len(clock.timers), 2)
+		assert.AreEqual(t, m.digests, [][]Notification{{
+			{Title: "1", Metadata: api},
+			{Title: "3", Metadata: api},
+		}})
+	})
+
+	t.Run("should report errors of digests delivered in the background", func(t *testing.T) {
+		var flushErr error
+		clock := &fakeClock{}
+		digest := NewDigest(&MockMessenger{
+			notifyFunc: func(_ context.Context, _ Notification) error {
+				return errors.New("service unavailable")
+			},
+		}, WithFlushErrorHandler(func(err error) {
+			flushErr = err
+		}))
+		digest.afterFunc = clock.AfterFunc
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1"})
+		clock.timers[0].f()
+
+		assert.AreEqual(t, flushErr.Error(), "service unavailable")
+	})
+
+	t.Run("should flush pending digests on close", func(t *testing.T) {
+		var titles []string
+		clock := &fakeClock{}
+		digest := NewDigest(recordingMessenger(&titles))
+		digest.afterFunc = clock.AfterFunc
+
+		_ = digest.Notify(context.Background(), Notification{Title: "1"})
+		err := digest.Close(context.Background())
+		clock.timers[0].f()
+		afterCloseErr := digest.Notify(context.Background(), Notification{Title: "2"})
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, afterCloseErr)
+		assert.AreEqual(t, clock.timers[0].stopped, true)
+		assert.AreEqual(t, titles, []string{"1", "2"})
+	})
+
+	t.Run("should return an error when the notification is invalid", func(t *testing.T) {
+		digest := NewDigest(&MockMessenger{})
+
+		err := digest.Notify(context.Background(), Notification{})
+
+		assert.AreEqual(t, err.Error(), "missing title or body")
+	})
+
+	t.Run("should send the configured message immediately", func(t *testing.T) {
+		calls := 0
+		digest := NewDigest(&MockMessenger{sendFunc: func(_ context.Context) error {
+			calls++
+			return nil
+		}})
+
+		_ = digest.Send(context.Background())
+
+		assert.AreEqual(t, calls, 1)
+	})
+}
+
+func TestCombine(t *testing.T) {
+	t.Run("should merge the notifications", func(t *testing.T) {
+		combined := Combine([]Notification{
+			{
+				Title:    "Disk full",
+				Body:     "95%",
+				Severity: SeverityWarning,
+				Metadata: map[string]string{"env": "prod", "host": "api-1"},
+				Links:    []Link{{Text: "Runbook", URL: "https://example.com"}},
+			},
+			{
+				Title:    "Database down",
+				Severity: SeverityCritical,
+				Metadata: map[string]string{"env": "prod", "host": "db-1"},
+				Fields:   []Field{{Name: "Host", Value: "db-1"}},
+			},
+		})
+
+		assert.AreEqual(t, combined, Notification{
+			Title:    "2 notifications",
+			Body:     "Disk full\n95%\n\nDatabase down",
+			Severity: SeverityCritical,
+			Metadata: map[string]string{"env": "prod"},
+			Fields:   []Field{{Name: "Host", Value: "db-1"}},
+			Links:    []Link{{Text: "Runbook", URL: "https://example.com"}},
+		})
+	})
+}
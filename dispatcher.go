@@ -0,0 +1,262 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultQueueSize = 100
+	defaultWorkers   = 4
+)
+
+var (
+	// ErrQueueFull is returned when the queue of a Dispatcher is full
+	// and its backpressure policy is BackpressureDropNewest.
+	ErrQueueFull = errors.New("queue full")
+	// ErrDropped is the error of deliveries evicted from a full queue
+	// when the backpressure policy is BackpressureDropOldest.
+	ErrDropped = errors.New("dropped")
+	// ErrDispatcherClosed is returned when dispatching after Shutdown was called.
+	ErrDispatcherClosed = errors.New("dispatcher closed")
+)
+
+// Backpressure is what a Dispatcher does when its queue is full.
+type Backpressure int
+
+const (
+	// BackpressureBlock waits for room in the queue or for the context to be done.
+	BackpressureBlock Backpressure = iota
+	// BackpressureDropOldest evicts the oldest queued delivery to make room.
+	BackpressureDropOldest
+	// BackpressureDropNewest rejects the new delivery with ErrQueueFull.
+	BackpressureDropNewest
+)
+
+// Delivery is the outcome of a delivery queued on a Dispatcher.
+// Notification is nil for Send.
+// Result is nil when the delivery was dropped, in which case Err is ErrDropped.
+type Delivery struct {
+	Err          error
+	Notification *Notification
+	Result       *SendResult
+}
+
+// Dispatcher delivers through Nofy in the background, so callers do not wait
+// for the messengers. Deliveries are queued in a bounded in-memory queue
+// and handled by a pool of workers.
+type Dispatcher struct {
+	notifier   *Nofy
+	queue      chan job
+	closing    chan struct{}
+	ctx        context.Context
+	cancel     context.CancelFunc
+	onDelivery func(Delivery)
+	workersWg  sync.WaitGroup
+	closeOnce  sync.Once
+	mu         sync.RWMutex
+	queueSize  int
+	workers    int
+	policy     Backpressure
+	closed     bool
+}
+
+type job struct {
+	ctx          context.Context
+	notification *Notification
+}
+
+type DispatcherOption func(*Dispatcher)
+
+// NewDispatcher starts a dispatcher delivering through the notifier.
+// By default it queues up to 100 deliveries, runs 4 workers and blocks when the queue is full.
+// Shutdown must be called to stop its workers.
+func NewDispatcher(notifier *Nofy, options ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		notifier:  notifier,
+		closing:   make(chan struct{}),
+		queueSize: defaultQueueSize,
+		workers:   defaultWorkers,
+		policy:    BackpressureBlock,
+	}
+
+	for _, opt := range options {
+		opt(d)
+	}
+
+	d.queue = make(chan job, d.queueSize)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	d.workersWg.Add(d.workers)
+	for range d.workers {
+		go d.work()
+	}
+
+	return d
+}
+
+// WithQueueSize sets how many deliveries can wait in the queue.
+func WithQueueSize(size int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queueSize = max(size, 1)
+	}
+}
+
+// WithWorkers sets how many deliveries are handled at the same time.
+func WithWorkers(workers int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = max(workers, 1)
+	}
+}
+
+// WithBackpressure sets what happens when the queue is full.
+func WithBackpressure(policy Backpressure) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.policy = policy
+	}
+}
+
+// WithDeliveryCallback sets a function called with the outcome of every queued delivery,
+// including the ones dropped from the queue.
+// It is called concurrently from the workers, and from the goroutine of Send or Notify
+// for the deliveries dropped by BackpressureDropOldest, so it must not block for long.
+func WithDeliveryCallback(onDelivery func(Delivery)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.onDelivery = onDelivery
+	}
+}
+
+// Send queues a SendAll.
+func (d *Dispatcher) Send(ctx context.Context) error {
+	return d.enqueue(ctx, job{ctx: ctx})
+}
+
+// Notify queues a NotifyAll of the notification.
+// The notification is validated before being queued.
+func (d *Dispatcher) Notify(ctx context.Context, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	return d.enqueue(ctx, job{ctx: ctx, notification: &n})
+}
+
+// Shutdown stops accepting deliveries and waits for the queued and in-flight ones to complete.
+// When the context is done first, the remaining deliveries are canceled and the error
+// of the context is returned.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.closeOnce.Do(func() {
+		close(d.closing)
+
+		// Blocked senders hold the read lock until they see closing.
+		d.mu.Lock()
+		d.closed = true
+		close(d.queue)
+		d.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		d.workersWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+		return fmt.Errorf("error draining dispatcher: %w", ctx.Err())
+	}
+}
+
+// enqueue adds the job to the queue according to the backpressure policy.
+// Dropped jobs are reported once the lock is released,
+// so the delivery callback can call Shutdown.
+func (d *Dispatcher) enqueue(ctx context.Context, j job) error {
+	dropped, err := d.push(ctx, j)
+	for _, job := range dropped {
+		d.report(Delivery{Err: ErrDropped, Notification: job.notification})
+	}
+	return err
+}
+
+// push adds the job to the queue and returns the jobs dropped to make room for it.
+func (d *Dispatcher) push(ctx context.Context, j job) ([]job, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, ErrDispatcherClosed
+	}
+
+	switch d.policy {
+	case BackpressureDropNewest:
+		select {
+		case d.queue <- j:
+			return nil, nil
+		default:
+			return nil, ErrQueueFull
+		}
+	case BackpressureDropOldest:
+		var dropped []job
+		for {
+			select {
+			case d.queue <- j:
+				return dropped, nil
+			default:
+			}
+
+			select {
+			case oldest := <-d.queue:
+				dropped = append(dropped, oldest)
+			default:
+			}
+		}
+	default:
+		select {
+		case d.queue <- j:
+			return nil, nil
+		case <-d.closing:
+			return nil, ErrDispatcherClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// work handles queued jobs until the queue is closed and drained.
+func (d *Dispatcher) work() {
+	defer d.workersWg.Done()
+
+	for j := range d.queue {
+		d.deliver(j)
+	}
+}
+
+// deliver sends the job with a context that keeps the values of the caller's context
+// but is only canceled when Shutdown gives up waiting.
+func (d *Dispatcher) deliver(j job) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(j.ctx))
+	defer cancel()
+	stop := context.AfterFunc(d.ctx, cancel)
+	defer stop()
+
+	var result *SendResult
+	if j.notification != nil {
+		result = d.notifier.NotifyAllResult(ctx, *j.notification)
+	} else {
+		result = d.notifier.SendAllResult(ctx)
+	}
+
+	d.report(Delivery{Err: result.Err(), Notification: j.notification, Result: result})
+}
+
+func (d *Dispatcher) report(delivery Delivery) {
+	if d.onDelivery != nil {
+		d.onDelivery(delivery)
+	}
+}
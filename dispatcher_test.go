@@ -0,0 +1,281 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+// blockingMessenger blocks every delivery until release is closed.
+func blockingMessenger(started chan<- string, release <-chan struct{}) *MockMessenger {
+	return &MockMessenger{
+		name: "slack",
+		notifyFunc: func(ctx context.Context, n Notification) error {
+			started <- n.Title
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// deliveries collects the deliveries reported to the callback.
+type deliveries struct {
+	items []Delivery
+	mu    sync.Mutex
+}
+
+func (d *deliveries) add(delivery Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = append(d.items, delivery)
+}
+
+func (d *deliveries) titles(err error) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var titles []string
+	for _, delivery := range d.items {
+		if errors.Is(delivery.Err, err) {
+			titles = append(titles, delivery.Notification.Title)
+		}
+	}
+	return titles
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("should deliver notifications in the background", func(t *testing.T) {
+		var got deliveries
+		dispatcher := NewDispatcher(
+			New(WithMessengers(&MockMessenger{name: "slack"})),
+			WithDeliveryCallback(got.add),
+		)
+
+		err := dispatcher.Notify(context.Background(), Notification{Title: "Deploy failed"})
+		shutdownErr := dispatcher.Shutdown(context.Background())
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, shutdownErr)
+		assert.AreEqual(t, got.titles(nil), []string{"Deploy failed"})
+		assert.AreEqual(t, got.items[0].Result.Results[0].Messenger, "slack")
+	})
+
+	t.Run("should queue a send of the configured messages", func(t *testing.T) {
+		sent := make(chan struct{}, 1)
+		dispatcher := NewDispatcher(New(WithMessengers(&MockMessenger{
+			sendFunc: func(_ context.Context) error {
+				sent <- struct{}{}
+				return nil
+			},
+		})))
+
+		err := dispatcher.Send(context.Background())
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(sent), 1)
+	})
+
+	t.Run("should report failed deliveries", func(t *testing.T) {
+		var got deliveries
+		dispatcher := NewDispatcher(
+			New(WithMessengers(&MockMessenger{
+				notifyFunc: func(_ context.Context, _ Notification) error {
+					return errors.New("service unavailable")
+				},
+			})),
+			WithDeliveryCallback(got.add),
+		)
+
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "Deploy failed"})
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.AreEqual(t, len(got.items), 1)
+		assert.AreEqual(t, got.items[0].Result.Failed()[0].Err.Error(), "service unavailable")
+	})
+
+	t.Run("should return an error when the notification is invalid", func(t *testing.T) {
+		dispatcher := NewDispatcher(New())
+		defer dispatcher.Shutdown(context.Background())
+
+		err := dispatcher.Notify(context.Background(), Notification{})
+
+		assert.AreEqual(t, err.Error(), "missing title or body")
+	})
+
+	t.Run("should not be canceled with the context of the caller", func(t *testing.T) {
+		var got deliveries
+		dispatcher := NewDispatcher(
+			New(WithMessengers(&MockMessenger{
+				notifyFunc: func(ctx context.Context, _ Notification) error {
+					return ctx.Err()
+				},
+			})),
+			WithDeliveryCallback(got.add),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		_ = dispatcher.Notify(ctx, Notification{Title: "Deploy failed"})
+		cancel()
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.AreEqual(t, got.titles(nil), []string{"Deploy failed"})
+	})
+
+	t.Run("should reject the newest notification when the queue is full", func(t *testing.T) {
+		started := make(chan string, 3)
+		release := make(chan struct{})
+		dispatcher := NewDispatcher(
+			New(WithMessengers(blockingMessenger(started, release))),
+			WithWorkers(1),
+			WithQueueSize(1),
+			WithBackpressure(BackpressureDropNewest),
+		)
+
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "1"})
+		<-started
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "2"})
+		err := dispatcher.Notify(context.Background(), Notification{Title: "3"})
+		close(release)
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.AreEqual(t, errors.Is(err, ErrQueueFull), true)
+	})
+
+	t.Run("should drop the oldest notification when the queue is full", func(t *testing.T) {
+		var got deliveries
+		started := make(chan string, 3)
+		release := make(chan struct{})
+		dispatcher := NewDispatcher(
+			New(WithMessengers(blockingMessenger(started, release))),
+			WithWorkers(1),
+			WithQueueSize(1),
+			WithBackpressure(BackpressureDropOldest),
+			WithDeliveryCallback(got.add),
+		)
+
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "1"})
+		<-started
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "2"})
+		err := dispatcher.Notify(context.Background(), Notification{Title: "3"})
+		close(release)
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.titles(ErrDropped), []string{"2"})
+		assert.AreEqual(t, got.titles(nil), []string{"1", "3"})
+	})
+
+	t.Run("should report dropped notifications outside the lock", func(t *testing.T) {
+		started := make(chan string, 3)
+		release := make(chan struct{})
+		var dispatcher *Dispatcher
+		var shutdownErr error
+		dispatcher = NewDispatcher(
+			New(WithMessengers(blockingMessenger(started, release))),
+			WithWorkers(1),
+			WithQueueSize(1),
+			WithBackpressure(BackpressureDropOldest),
+			WithDeliveryCallback(func(delivery Delivery) {
+				if errors.Is(delivery.Err, ErrDropped) {
+					close(release)
+					shutdownErr = dispatcher.Shutdown(context.Background())
+				}
+			}),
+		)
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "1"})
+		<-started
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "2"})
+		done := make(chan error)
+
+		go func() { done <- dispatcher.Notify(context.Background(), Notification{Title: "3"}) }()
+
+		select {
+		case err := <-done:
+			assert.IsNil(t, err)
+			assert.IsNil(t, shutdownErr)
+		case <-time.After(time.Second):
+			t.Fatal("Expected Shutdown from the callback not to deadlock")
+		}
+	})
+
+	t.Run("should block until the context is done when the queue is full", func(t *testing.T) {
+		started := make(chan string, 3)
+		release := make(chan struct{})
+		dispatcher := NewDispatcher(
+			New(WithMessengers(blockingMessenger(started, release))),
+			WithWorkers(1),
+			WithQueueSize(1),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "1"})
+		<-started
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "2"})
+		err := dispatcher.Notify(ctx, Notification{Title: "3"})
+		close(release)
+		_ = dispatcher.Shutdown(context.Background())
+
+		assert.AreEqual(t, errors.Is(err, context.DeadlineExceeded), true)
+	})
+
+	t.Run("should drain queued notifications on shutdown", func(t *testing.T) {
+		var got deliveries
+		dispatcher := NewDispatcher(
+			New(WithMessengers(&MockMessenger{
+				notifyFunc: func(_ context.Context, _ Notification) error {
+					time.Sleep(time.Millisecond)
+					return nil
+				},
+			})),
+			WithWorkers(1),
+			WithDeliveryCallback(got.add),
+		)
+
+		for _, title := range []string{"1", "2", "3"} {
+			_ = dispatcher.Notify(context.Background(), Notification{Title: title})
+		}
+		err := dispatcher.Shutdown(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.titles(nil), []string{"1", "2", "3"})
+	})
+
+	t.Run("should cancel in-flight notifications when shutdown times out", func(t *testing.T) {
+		var got deliveries
+		started := make(chan string, 1)
+		dispatcher := NewDispatcher(
+			New(WithMessengers(blockingMessenger(started, make(chan struct{})))),
+			WithDeliveryCallback(got.add),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_ = dispatcher.Notify(context.Background(), Notification{Title: "1"})
+		<-started
+		err := dispatcher.Shutdown(ctx)
+
+		assert.AreEqual(t, errors.Is(err, context.DeadlineExceeded), true)
+		assert.AreEqual(t, got.titles(context.Canceled), []string{"1"})
+	})
+
+	t.Run("should reject notifications after shutdown", func(t *testing.T) {
+		dispatcher := NewDispatcher(New())
+		_ = dispatcher.Shutdown(context.Background())
+
+		err := dispatcher.Notify(context.Background(), Notification{Title: "Deploy failed"})
+		shutdownErr := dispatcher.Shutdown(context.Background())
+
+		assert.AreEqual(t, errors.Is(err, ErrDispatcherClosed), true)
+		assert.IsNil(t, shutdownErr)
+	})
+}
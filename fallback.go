@@ -0,0 +1,124 @@
+package nofy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Fallback is a messenger that tries a list of messengers in order
+// until one of them succeeds, e.g. Slack first, then email, then SMS.
+type Fallback struct {
+	messengers []Messenger
+}
+
+// FallbackReport describes a delivery through a Fallback.
+// Delivered is the name of the messenger that delivered and Index its position,
+// Delivered is empty and Index is -1 when every messenger failed.
+// Errors contains a *MessengerError for every messenger that failed.
+type FallbackReport struct {
+	Delivered string
+	Errors    []error
+	Index     int
+}
+
+// FallbackError is returned when every messenger of a Fallback failed.
+// It wraps a *MessengerError for every messenger.
+type FallbackError struct {
+	Errors []error
+}
+
+func (e *FallbackError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("all messengers failed: %s", strings.Join(messages, "; "))
+}
+
+func (e *FallbackError) Unwrap() []error {
+	return e.Errors
+}
+
+// NewFallback creates a messenger that tries the messengers in the given order.
+func NewFallback(messengers ...Messenger) *Fallback {
+	return &Fallback{
+		messengers: messengers,
+	}
+}
+
+// Name returns the name of the fallback chain, e.g. fallback(slack, resend).
+func (f *Fallback) Name() string {
+	names := make([]string, 0, len(f.messengers))
+	for _, m := range f.messengers {
+		names = append(names, NameOf(m))
+	}
+	return fmt.Sprintf("fallback(%s)", strings.Join(names, ", "))
+}
+
+// Send sends the configured message of each messenger until one succeeds.
+func (f *Fallback) Send(ctx context.Context) error {
+	_, err := f.SendReport(ctx)
+	return err
+}
+
+// SendReport sends the configured message of each messenger until one succeeds
+// and reports which messenger delivered.
+func (f *Fallback) SendReport(ctx context.Context) (FallbackReport, error) {
+	return f.try(ctx, func(ctx context.Context, m Messenger) error {
+		return m.Send(ctx)
+	})
+}
+
+// Notify delivers the notification through each messenger until one succeeds.
+func (f *Fallback) Notify(ctx context.Context, n Notification) error {
+	_, err := f.NotifyReport(ctx, n)
+	return err
+}
+
+// NotifyReport delivers the notification through each messenger until one succeeds
+// and reports which messenger delivered.
+func (f *Fallback) NotifyReport(ctx context.Context, n Notification) (FallbackReport, error) {
+	return f.try(ctx, func(ctx context.Context, m Messenger) error {
+		return m.Notify(ctx, n)
+	})
+}
+
+// try calls send with each messenger in order until one succeeds.
+// It stops early when the context is done.
+func (f *Fallback) try(
+	ctx context.Context,
+	send func(context.Context, Messenger) error,
+) (FallbackReport, error) {
+	report := FallbackReport{Index: -1}
+
+	if len(f.messengers) == 0 {
+		return report, fmt.Errorf("missing messengers")
+	}
+
+	for i, m := range f.messengers {
+		if err := ctx.Err(); err != nil {
+			report.Errors = append(report.Errors, &MessengerError{
+				Err:       err,
+				Messenger: NameOf(m),
+				Index:     i,
+			})
+			break
+		}
+
+		err := call(ctx, m, send)
+		if err == nil {
+			report.Delivered = NameOf(m)
+			report.Index = i
+			return report, nil
+		}
+
+		report.Errors = append(report.Errors, &MessengerError{
+			Err:       err,
+			Messenger: NameOf(m),
+			Index:     i,
+		})
+	}
+
+	return report, &FallbackError{Errors: report.Errors}
+}
@@ -0,0 +1,121 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestFallback(t *testing.T) {
+	t.Run("should stop at the first messenger that succeeds", func(t *testing.T) {
+		called := false
+		fallback := NewFallback(
+			&MockMessenger{name: "slack"},
+			&MockMessenger{name: "resend", sendFunc: func(_ context.Context) error {
+				called = true
+				return nil
+			}},
+		)
+
+		report, err := fallback.SendReport(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, report.Delivered, "slack")
+		assert.AreEqual(t, report.Index, 0)
+		assert.AreEqual(t, len(report.Errors), 0)
+		assert.AreEqual(t, called, false, "Expected second messenger not to be called")
+	})
+
+	t.Run("should try the next messenger when one fails", func(t *testing.T) {
+		fallback := NewFallback(
+			&MockMessenger{name: "slack", notifyFunc: func(_ context.Context, _ Notification) error {
+				return errors.New("rate limited")
+			}},
+			&MockMessenger{name: "resend", notifyFunc: func(_ context.Context, _ Notification) error {
+				panic("unexpected panic")
+			}},
+			&MockMessenger{name: "sms"},
+		)
+
+		report, err := fallback.NotifyReport(context.Background(), Notification{Title: "Disk full"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, report.Delivered, "sms")
+		assert.AreEqual(t, report.Index, 2)
+		assert.AreEqual(t, len(report.Errors), 2)
+		assert.AreEqualErrs(t, report.Errors[0], errors.New("slack: rate limited"))
+		assert.AreEqualErrs(t, report.Errors[1], errors.New("resend: panic recovered: unexpected panic"))
+	})
+
+	t.Run("should return a FallbackError when every messenger fails", func(t *testing.T) {
+		errRateLimited := errors.New("rate limited")
+		fallback := NewFallback(
+			&MockMessenger{name: "slack", sendFunc: func(_ context.Context) error {
+				return errRateLimited
+			}},
+			&MockMessenger{name: "resend", sendFunc: func(_ context.Context) error {
+				return errors.New("invalid recipient")
+			}},
+		)
+
+		err := fallback.Send(context.Background())
+
+		var fallbackErr *FallbackError
+		assert.AreEqualErrs(
+			t,
+			err,
+			errors.New("all messengers failed: slack: rate limited; resend: invalid recipient"),
+		)
+		assert.AreEqual(t, errors.As(err, &fallbackErr), true)
+		assert.AreEqual(t, len(fallbackErr.Errors), 2)
+		assert.AreEqual(t, errors.Is(err, errRateLimited), true)
+	})
+
+	t.Run("should stop trying when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		called := false
+		fallback := NewFallback(
+			&MockMessenger{name: "slack", sendFunc: func(_ context.Context) error {
+				cancel()
+				return context.Canceled
+			}},
+			&MockMessenger{name: "resend", sendFunc: func(_ context.Context) error {
+				called = true
+				return nil
+			}},
+		)
+
+		report, err := fallback.SendReport(ctx)
+
+		assert.AreEqual(t, errors.Is(err, context.Canceled), true)
+		assert.AreEqual(t, report.Index, -1)
+		assert.AreEqual(t, called, false, "Expected second messenger not to be called")
+	})
+
+	t.Run("should return error when there are no messengers", func(t *testing.T) {
+		err := NewFallback().Send(context.Background())
+
+		assert.AreEqualErrs(t, err, errors.New("missing messengers"))
+	})
+
+	t.Run("should name the chain after its messengers", func(t *testing.T) {
+		fallback := NewFallback(&MockMessenger{name: "slack"}, &MockMessenger{name: "resend"})
+
+		assert.AreEqual(t, NameOf(fallback), "fallback(slack, resend)")
+	})
+
+	t.Run("should be usable as a messenger of Nofy", func(t *testing.T) {
+		s := NewWithMessengers(NewFallback(
+			&MockMessenger{name: "slack", sendFunc: func(_ context.Context) error {
+				return errors.New("rate limited")
+			}},
+			&MockMessenger{name: "resend"},
+		))
+
+		err := s.SendAll(context.Background())
+
+		assert.IsNil(t, err)
+	})
+}
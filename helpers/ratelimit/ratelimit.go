@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is the number of buckets above which full buckets are pruned.
+const maxIdleBuckets = 1024
+
+// ErrLimited matches errors returned when a key is over its limit.
+var ErrLimited = errors.New("rate limited")
+
+// LimitError is returned in fail fast mode when a key is over its limit.
+// RetryAfter is how long to wait until a token is available.
+type LimitError struct {
+	Key        string
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s: retry after %s", e.Key, e.RetryAfter)
+}
+
+// Is reports whether target is ErrLimited.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimited
+}
+
+// Limiter is a set of token buckets keyed by string,
+// e.g. by messenger, channel or recipient.
+// Every bucket holds up to burst tokens and gets a new token every interval.
+type Limiter struct {
+	now      func() time.Time
+	sleep    func(ctx context.Context, delay time.Duration) error
+	buckets  map[string]*bucket
+	interval time.Duration
+	burst    int
+	failFast bool
+	mu       sync.Mutex
+}
+
+type bucket struct {
+	updated time.Time
+	tokens  float64
+}
+
+type Option func(*Limiter)
+
+// New creates a limiter that allows one request every interval per key,
+// with bursts of up to burst requests.
+// For example New(time.Second, 1) allows one request per second.
+func New(interval time.Duration, burst int, options ...Option) *Limiter {
+	limiter := &Limiter{
+		now:      time.Now,
+		sleep:    sleep,
+		buckets:  make(map[string]*bucket),
+		interval: interval,
+		burst:    max(burst, 1),
+	}
+
+	for _, opt := range options {
+		opt(limiter)
+	}
+
+	return limiter
+}
+
+// WithFailFast makes Wait return a *LimitError instead of blocking
+// when the key is over its limit.
+func WithFailFast() Option {
+	return func(l *Limiter) {
+		l.failFast = true
+	}
+}
+
+// Allow reports whether a request for the key is allowed now, consuming a token if so.
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Wait consumes a token for the key.
+// It blocks until the token is available or the context is done,
+// or returns a *LimitError right away in fail fast mode.
+func (l *Limiter) Wait(ctx context.Context, key string) error {
+	l.mu.Lock()
+	b := l.bucket(key)
+	if b.tokens >= 1 {
+		b.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	delay := time.Duration((1 - b.tokens) * float64(l.interval))
+	if l.failFast {
+		l.mu.Unlock()
+		return &LimitError{Key: key, RetryAfter: delay}
+	}
+
+	// Reserve the token so waiters are served in order.
+	b.tokens--
+	l.mu.Unlock()
+
+	if err := l.sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.bucket(key).tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// bucket returns the bucket of the key refilled up to now.
+// It must be called with the lock held.
+func (l *Limiter) bucket(key string) *bucket {
+	now := l.now()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.prune()
+		}
+		b = &bucket{tokens: float64(l.burst), updated: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if l.interval > 0 {
+		elapsed := now.Sub(b.updated)
+		b.tokens = min(b.tokens+float64(elapsed)/float64(l.interval), float64(l.burst))
+	} else {
+		b.tokens = float64(l.burst)
+	}
+	b.updated = now
+
+	return b
+}
+
+// prune removes the buckets that are full, since they are equivalent to new ones.
+// It must be called with the lock held.
+func (l *Limiter) prune() {
+	now := l.now()
+	for key, b := range l.buckets {
+		if l.interval <= 0 ||
+			b.tokens+float64(now.Sub(b.updated))/float64(l.interval) >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// sleep waits for the given delay or until the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(_ context.Context, delay time.Duration) error {
+	c.now = c.now.Add(delay)
+	return nil
+}
+
+func TestAllow(t *testing.T) {
+	t.Run("should allow bursts up to the burst size", func(t *testing.T) {
+		limiter := New(time.Hour, 2)
+
+		assert.AreEqual(t, limiter.Allow("channel"), true)
+		assert.AreEqual(t, limiter.Allow("channel"), true)
+		assert.AreEqual(t, limiter.Allow("channel"), false)
+	})
+
+	t.Run("should refill tokens over time", func(t *testing.T) {
+		clock := &fakeClock{}
+		limiter := New(time.Second, 1)
+		limiter.now, limiter.sleep = clock.Now, clock.Sleep
+		limiter.Allow("channel")
+
+		clock.now = clock.now.Add(time.Second)
+
+		assert.AreEqual(t, limiter.Allow("channel"), true)
+	})
+
+	t.Run("should keep a bucket per key", func(t *testing.T) {
+		limiter := New(time.Hour, 1)
+
+		assert.AreEqual(t, limiter.Allow("first"), true)
+		assert.AreEqual(t, limiter.Allow("second"), true)
+		assert.AreEqual(t, limiter.Allow("first"), false)
+	})
+}
+
+func TestWait(t *testing.T) {
+	t.Run("should wait until a token is available", func(t *testing.T) {
+		clock := &fakeClock{}
+		limiter := New(time.Second, 1)
+		limiter.now, limiter.sleep = clock.Now, clock.Sleep
+		start := clock.now
+
+		for range 3 {
+			err := limiter.Wait(context.Background(), "channel")
+			assert.IsNil(t, err)
+		}
+
+		assert.AreEqual(t, clock.now.Sub(start), 2*time.Second)
+	})
+
+	t.Run("should fail fast with a LimitError", func(t *testing.T) {
+		clock := &fakeClock{}
+		limiter := New(time.Second, 1, WithFailFast())
+		limiter.now = clock.Now
+		_ = limiter.Wait(context.Background(), "channel")
+
+		err := limiter.Wait(context.Background(), "channel")
+
+		var limitErr *LimitError
+		assert.AreEqual(t, errors.Is(err, ErrLimited), true)
+		assert.AreEqual(t, errors.As(err, &limitErr), true)
+		assert.AreEqual(t, limitErr.Key, "channel")
+		assert.AreEqual(t, limitErr.RetryAfter, time.Second)
+		assert.AreEqualErrs(t, err, errors.New("rate limit exceeded for channel: retry after 1s"))
+	})
+
+	t.Run("should give back the token when the context is done", func(t *testing.T) {
+		limiter := New(time.Hour, 1)
+		_ = limiter.Wait(context.Background(), "channel")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := limiter.Wait(ctx, "channel")
+
+		assert.AreEqualErrs(t, err, context.Canceled)
+		assert.AreEqual(t, limiter.buckets["channel"].tokens < 0, false, "Expected token to be given back")
+	})
+}
+
+func TestPrune(t *testing.T) {
+	t.Run("should remove full buckets", func(t *testing.T) {
+		clock := &fakeClock{}
+		limiter := New(time.Second, 1)
+		limiter.now, limiter.sleep = clock.Now, clock.Sleep
+		limiter.Allow("used")
+		limiter.Allow("idle")
+		clock.now = clock.now.Add(time.Second)
+		limiter.Allow("used")
+
+		limiter.prune()
+
+		_, hasIdle := limiter.buckets["idle"]
+		_, hasUsed := limiter.buckets["used"]
+		assert.AreEqual(t, hasIdle, false, "Expected full bucket to be removed")
+		assert.AreEqual(t, hasUsed, true, "Expected used bucket to be kept")
+	})
+}
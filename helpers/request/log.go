@@ -0,0 +1,106 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are redacted entirely when logged.
+var sensitiveHeaders = []string{"cookie", "token", "secret", "key", "signature"}
+
+// WithLogger sets the logger used to report every attempt of the request.
+// Secrets such as the Authorization header are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *request) {
+		r.logger = logger
+	}
+}
+
+// log writes a record when a logger is set.
+func (r *request) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if r.logger == nil {
+		return
+	}
+
+	attrs = append(
+		[]slog.Attr{
+			slog.String("method", r.method),
+			slog.String("url", r.loggedURL()),
+			slog.Any("headers", redactedHeaders(r.headers)),
+		},
+		attrs...,
+	)
+	r.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// redactedHeaders logs headers without their secrets.
+type redactedHeaders map[string]string
+
+// LogValue implements slog.LogValuer.
+func (h redactedHeaders) LogValue() slog.Value {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.String(key, redactHeader(key, h[key])))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redactHeader hides the credentials of the header value.
+// The scheme of the Authorization header is kept, e.g. "Bearer [REDACTED]".
+func redactHeader(key, value string) string {
+	name := strings.ToLower(key)
+
+	if name == "authorization" || name == "proxy-authorization" {
+		if scheme, _, found := strings.Cut(value, " "); found {
+			return scheme + " " + redacted
+		}
+		return redacted
+	}
+
+	for _, sensitive := range sensitiveHeaders {
+		if strings.Contains(name, sensitive) {
+			return redacted
+		}
+	}
+
+	return value
+}
+
+// loggedURL returns the URL of the request, redacted when it is a secret.
+func (r *request) loggedURL() string {
+	if !r.secretURL {
+		return r.url
+	}
+	return redactURL(r.url)
+}
+
+// redactError hides a secret URL from the errors of the HTTP client,
+// which quote the URL of the request.
+func (r *request) redactError(err error) error {
+	var urlErr *url.Error
+	if r.secretURL && errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	return err
+}
+
+// redactURL keeps the scheme and host of the URL, e.g. "https://hooks.slack.com/[REDACTED]".
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return redacted
+	}
+	return parsed.Scheme + "://" + parsed.Host + "/" + redacted
+}
@@ -0,0 +1,118 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestRedactHeader(t *testing.T) {
+	t.Run("should keep the scheme of the Authorization header", func(t *testing.T) {
+		assert.AreEqual(t, redactHeader("Authorization", "Bearer xoxb-secret"), "Bearer [REDACTED]")
+	})
+
+	t.Run("should redact Authorization headers without scheme", func(t *testing.T) {
+		assert.AreEqual(t, redactHeader("Authorization", "xoxb-secret"), "[REDACTED]")
+	})
+
+	t.Run("should redact headers with sensitive names", func(t *testing.T) {
+		assert.AreEqual(t, redactHeader("X-Api-Key", "secret"), "[REDACTED]")
+		assert.AreEqual(t, redactHeader("Cookie", "session=secret"), "[REDACTED]")
+	})
+
+	t.Run("should keep other headers", func(t *testing.T) {
+		assert.AreEqual(t, redactHeader("Content-Type", "application/json"), "application/json")
+	})
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("should log every attempt without secrets", func(t *testing.T) {
+		var buf bytes.Buffer
+		calls := 0
+		mockClient := MockHTTPClient{
+			DoFunc: func(_ *http.Request) (*http.Response, error) {
+				calls++
+				if calls == 1 {
+					return nil, errors.New("connection reset by peer")
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			},
+		}
+		requester := &request{
+			client: mockClient,
+			logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			retry:  RetryPolicy{MaxAttempts: 2},
+			sleep:  func(_ context.Context, _ time.Duration) error { return nil },
+		}
+
+		_, _, err := requester.Do(
+			context.Background(),
+			WithMethod(http.MethodPost),
+			WithURL("https://example.com"),
+			WithHeader("Authorization", "Bearer xoxb-secret"),
+		)
+
+		logs := buf.String()
+		assert.IsNil(t, err)
+		assert.AreEqual(t, strings.Count(logs, "msg="), 3, "Expected failed, retrying and completed logs")
+		assert.AreEqual(t, strings.Contains(logs, `msg="request failed"`), true)
+		assert.AreEqual(t, strings.Contains(logs, `msg="retrying request"`), true)
+		assert.AreEqual(t, strings.Contains(logs, `msg="request completed"`), true)
+		assert.AreEqual(t, strings.Contains(logs, "status_code=200"), true)
+		assert.AreEqual(t, strings.Contains(logs, `headers.Authorization="Bearer [REDACTED]"`), true)
+		assert.AreEqual(t, strings.Contains(logs, "xoxb-secret"), false, "Expected token to be redacted")
+	})
+
+	t.Run("should redact secret urls from logs and errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		mockClient := MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, &url.Error{
+					Op:  "Post",
+					URL: req.URL.String(),
+					Err: errors.New("connection refused"),
+				}
+			},
+		}
+		requester := &request{
+			client: mockClient,
+			logger: slog.New(slog.NewTextHandler(&buf, nil)),
+		}
+
+		_, _, err := requester.Do(
+			context.Background(),
+			WithMethod(http.MethodPost),
+			WithSecretURL("https://hooks.slack.com/services/T000/B000/secret"),
+		)
+
+		logs := buf.String()
+		assert.AreEqual(
+			t,
+			err.Error(),
+			`error sending request: Post "https://hooks.slack.com/[REDACTED]": connection refused`,
+		)
+		assert.AreEqual(t, strings.Contains(logs, "url=https://hooks.slack.com/[REDACTED]"), true)
+		assert.AreEqual(t, strings.Contains(logs, "secret"), false, "Expected url to be redacted")
+	})
+
+	t.Run("should set logger with WithLogger option", func(t *testing.T) {
+		r := &request{}
+		logger := slog.Default()
+
+		WithLogger(logger)(r)
+
+		assert.AreEqual(t, r.logger, logger)
+	})
+}
@@ -3,18 +3,28 @@ package request
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"time"
 )
 
 type request struct {
 	client  HTTPClient
 	headers map[string]string
-
-	method  string
-	url     string
-	payload []byte
+	sleep   func(ctx context.Context, delay time.Duration) error
+
+	method    string
+	url       string
+	payload   []byte
+	body      io.Reader
+	bodySize  int64
+	logger    *slog.Logger
+	retry     RetryPolicy
+	timeout   time.Duration
+	secretURL bool
 }
 
 type Requester interface {
@@ -27,10 +37,17 @@ type HTTPClient interface {
 
 type Option func(*request)
 
-func NewRequester() Requester {
-	return &request{
+// NewRequester creates a new Requester.
+// The given options are applied to every request, before the options given to Do.
+func NewRequester(options ...Option) Requester {
+	r := &request{
 		client: http.DefaultClient,
+		sleep:  sleep,
+	}
+	for _, opt := range options {
+		opt(r)
 	}
+	return r
 }
 
 // WithMethod sets the method for the request.
@@ -44,6 +61,16 @@ func WithMethod(method string) Option {
 func WithURL(url string) Option {
 	return func(r *request) {
 		r.url = url
+		r.secretURL = false
+	}
+}
+
+// WithSecretURL sets a URL that is itself a credential, e.g. a webhook URL.
+// Only its scheme and host are logged or reported in errors.
+func WithSecretURL(url string) Option {
+	return func(r *request) {
+		r.url = url
+		r.secretURL = true
 	}
 }
 
@@ -71,10 +98,22 @@ func WithPayload(payload []byte) Option {
 	}
 }
 
+// WithBody streams the body of the request from the reader instead of a payload,
+// e.g. to upload a file without loading it in memory.
+// Size is the length of the body, or -1 when unknown.
+// The body cannot be read twice, so the request is never retried.
+func WithBody(body io.Reader, size int64) Option {
+	return func(r *request) {
+		r.body = body
+		r.bodySize = size
+	}
+}
+
 // Do sends a request to the given URL with the given method, headers, and payload.
 // It returns the response from the server and the body of the response.
+// Failed attempts are retried according to the retry policy.
 func (r *request) Do(ctx context.Context, options ...Option) (*http.Response, []byte, error) {
-	rq := &request{}
+	rq := r.clone()
 	for _, opt := range options {
 		opt(rq)
 	}
@@ -83,34 +122,111 @@ func (r *request) Do(ctx context.Context, options ...Option) (*http.Response, []
 		return nil, nil, err
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		rq.method,
-		rq.url,
-		bytes.NewBuffer(rq.payload),
-	)
+	for attempt := 1; ; attempt++ {
+		countAttempt(ctx)
+		start := time.Now()
+		resp, bodyResponse, err := rq.attempt(ctx)
+		rq.logAttempt(ctx, attempt, time.Since(start), resp, err)
+
+		if errors.Is(err, errCreatingRequest) || rq.body != nil ||
+			!rq.retry.shouldRetry(ctx, attempt, resp, err) {
+			return resp, bodyResponse, err
+		}
+
+		delay := rq.retry.delay(attempt, resp)
+		rq.log(ctx, slog.LevelWarn, "retrying request",
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+		)
+
+		if err := rq.sleep(ctx, delay); err != nil {
+			return nil, nil, fmt.Errorf("error waiting to retry request: %w", err)
+		}
+	}
+}
+
+// logAttempt reports the outcome of an attempt.
+func (r *request) logAttempt(
+	ctx context.Context,
+	attempt int,
+	latency time.Duration,
+	resp *http.Response,
+	err error,
+) {
+	attrs := []slog.Attr{
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+
 	if err != nil {
-		return nil, nil, fmt.Errorf("error creating request: %w", err)
+		attrs = append(attrs, slog.Any("error", err))
+		r.log(ctx, slog.LevelWarn, "request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status_code", resp.StatusCode))
+	r.log(ctx, slog.LevelDebug, "request completed", attrs...)
+}
+
+var errCreatingRequest = errors.New("error creating request")
+
+// attempt sends a single attempt of the request within its timeout
+// and reads the response body.
+func (r *request) attempt(ctx context.Context) (*http.Response, []byte, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
 	}
 
-	for header, headerValue := range rq.headers {
+	var body io.Reader = bytes.NewBuffer(r.payload)
+	if r.body != nil {
+		// The caller owns the reader, the client must not close it.
+		body = io.NopCloser(r.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errCreatingRequest, r.redactError(err))
+	}
+	if r.body != nil {
+		req.ContentLength = r.bodySize
+	}
+
+	for header, headerValue := range r.headers {
 		req.Header.Set(header, headerValue)
 	}
 
-	resp, err := rq.client.Do(req)
+	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error sending request: %w", err)
+		return nil, nil, fmt.Errorf(
+			"error sending request: %w",
+			asTimeout(r.redactError(err), r.timeout),
+		)
 	}
+	defer resp.Body.Close()
 
 	bodyResponse, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, nil, fmt.Errorf("error reading response body: %w", asTimeout(err, r.timeout))
 	}
-	defer resp.Body.Close()
 
 	return resp, bodyResponse, nil
 }
 
+// clone returns a copy of the request that can be modified by options.
+func (r *request) clone() *request {
+	rq := *r
+	rq.headers = make(map[string]string, len(r.headers))
+	for header, headerValue := range r.headers {
+		rq.headers[header] = headerValue
+	}
+	if rq.sleep == nil {
+		rq.sleep = sleep
+	}
+	return &rq
+}
+
 // any is a type that can hold any value.
 func validate(r *request) error {
 	if r.method == "" {
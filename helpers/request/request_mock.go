@@ -2,7 +2,9 @@ package request
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 )
 
 type MockRequester struct {
@@ -12,3 +14,35 @@ type MockRequester struct {
 func (m *MockRequester) Do(ctx context.Context, options ...Option) (*http.Response, []byte, error) {
 	return m.DoFunc(ctx, options...)
 }
+
+// MockRequest exposes the request built from a list of options,
+// so tests can inspect what a messenger sends through a MockRequester.
+type MockRequest struct {
+	Headers   map[string]string
+	Method    string
+	URL       string
+	Payload   []byte
+	Body      io.Reader
+	BodySize  int64
+	Timeout   time.Duration
+	SecretURL bool
+}
+
+// NewMockRequest applies the options and returns the resulting request.
+func NewMockRequest(options ...Option) MockRequest {
+	rq := &request{}
+	for _, opt := range options {
+		opt(rq)
+	}
+
+	return MockRequest{
+		Headers:   rq.headers,
+		Method:    rq.method,
+		URL:       rq.url,
+		Payload:   rq.payload,
+		Body:      rq.body,
+		BodySize:  rq.bodySize,
+		Timeout:   rq.timeout,
+		SecretURL: rq.secretURL,
+	}
+}
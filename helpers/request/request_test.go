@@ -113,6 +113,47 @@ func TestWith(t *testing.T) {
 
 		WithPayload(payloadBytes)(r)
 	})
+
+	t.Run("should set retry policy with WithRetry option", func(t *testing.T) {
+		r := &request{}
+
+		WithRetry(RetryPolicy{MaxAttempts: 3})(r)
+
+		assert.AreEqual(t, r.retry.MaxAttempts, 3, "Expected retry policy to be set")
+	})
+}
+
+func TestNewRequester(t *testing.T) {
+	t.Run("should apply options to every request", func(t *testing.T) {
+		var got *http.Request
+		mockClient := MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				got = req
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			},
+		}
+		requester := NewRequester(WithClient(mockClient), WithHeader("User-Agent", "nofy"))
+
+		_, _, err := requester.Do(
+			context.Background(),
+			WithMethod(http.MethodGet),
+			WithURL("https://example.com"),
+			WithHeader("Accept", "application/json"),
+		)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.Header.Get("User-Agent"), "nofy")
+		assert.AreEqual(t, got.Header.Get("Accept"), "application/json")
+		assert.AreEqual(
+			t,
+			len(requester.(*request).headers),
+			1,
+			"Expected options given to Do not to leak into the requester",
+		)
+	})
 }
 
 func TestDo(t *testing.T) {
@@ -251,3 +292,54 @@ func TestDo(t *testing.T) {
 		)
 	})
 }
+
+func TestDoBody(t *testing.T) {
+	t.Run("should stream the body with its length", func(t *testing.T) {
+		var body string
+		var length int64
+		mockClient := MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				data, _ := io.ReadAll(req.Body)
+				body, length = string(data), req.ContentLength
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			},
+		}
+
+		_, _, err := NewRequester(WithClient(mockClient)).Do(
+			context.Background(),
+			WithMethod(http.MethodPost),
+			WithURL("https://example.com"),
+			WithBody(strings.NewReader("id,status\n1,failed\n"), 19),
+		)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, body, "id,status\n1,failed\n")
+		assert.AreEqual(t, length, int64(19))
+	})
+
+	t.Run("should not retry requests with a streamed body", func(t *testing.T) {
+		calls := 0
+		mockClient := MockHTTPClient{
+			DoFunc: func(_ *http.Request) (*http.Response, error) {
+				calls++
+				return nil, errors.New("connection reset by peer")
+			},
+		}
+
+		_, _, err := NewRequester(
+			WithClient(mockClient),
+			WithRetry(RetryPolicy{MaxAttempts: 3}),
+		).Do(
+			context.Background(),
+			WithMethod(http.MethodPost),
+			WithURL("https://example.com"),
+			WithBody(strings.NewReader("log"), 3),
+		)
+
+		assert.AreEqualErrs(t, err, errors.New("error sending request: connection reset by peer"))
+		assert.AreEqual(t, calls, 1)
+	})
+}
@@ -0,0 +1,150 @@
+package request
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+// MaxAttempts is the maximum number of attempts, including the first one.
+// A zero policy makes a single attempt.
+// BaseDelay is the delay before the first retry; it doubles on every retry up to MaxDelay.
+// Jitter randomizes each delay by up to the given fraction (0 to 1) to spread retries.
+// Retryable classifies which status codes are retried; it defaults to RetryableStatus.
+// Transport errors are always retried while the context is not done.
+// A Retry-After header on the response takes precedence over the computed delay.
+type RetryPolicy struct {
+	Retryable   func(statusCode int) bool
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and
+// an exponential backoff from 500ms to 10s with 20% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Retryable:   RetryableStatus,
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// RetryableStatus reports whether the status code indicates a transient failure:
+// request timeout, too early, too many requests and 5xx gateway or availability errors.
+func RetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithRetry sets the retry policy for the request.
+func WithRetry(policy RetryPolicy) Option {
+	return func(r *request) {
+		r.retry = policy
+	}
+}
+
+// shouldRetry reports whether another attempt must be made
+// after the given attempt returned resp or err.
+func (p RetryPolicy) shouldRetry(ctx context.Context, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = RetryableStatus
+	}
+	return retryable(resp.StatusCode)
+}
+
+// delay returns how long to wait before the attempt following the given one.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay < 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		spread := float64(delay) * min(p.Jitter, 1)
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*2*spread) // #nosec G404
+		if p.MaxDelay > 0 {
+			delay = min(delay, p.MaxDelay)
+		}
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp); ok && retryAfter > delay {
+		return retryAfter
+	}
+
+	return delay
+}
+
+// parseRetryAfter decodes the Retry-After header, in seconds or as an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given delay or until the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type attemptCounterKey struct{}
+
+// ContextWithAttemptCounter returns a copy of ctx in which every attempt made
+// by Do is added to counter, so callers can report how many requests a send took.
+func ContextWithAttemptCounter(ctx context.Context, counter *atomic.Int32) context.Context {
+	return context.WithValue(ctx, attemptCounterKey{}, counter)
+}
+
+func countAttempt(ctx context.Context) {
+	if counter, ok := ctx.Value(attemptCounterKey{}).(*atomic.Int32); ok {
+		counter.Add(1)
+	}
+}
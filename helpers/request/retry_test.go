@@ -0,0 +1,241 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func newResponse(statusCode int, header http.Header) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(http.StatusText(statusCode))),
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	t.Run("should retry retryable status codes", func(t *testing.T) {
+		resp := newResponse(http.StatusBadGateway, nil)
+
+		assert.AreEqual(t, policy.shouldRetry(context.Background(), 1, resp, nil), true)
+	})
+
+	t.Run("should not retry other status codes", func(t *testing.T) {
+		resp := newResponse(http.StatusUnprocessableEntity, nil)
+
+		assert.AreEqual(t, policy.shouldRetry(context.Background(), 1, resp, nil), false)
+	})
+
+	t.Run("should retry transport errors", func(t *testing.T) {
+		err := errors.New("connection reset by peer")
+
+		assert.AreEqual(t, policy.shouldRetry(context.Background(), 1, nil, err), true)
+	})
+
+	t.Run("should not retry when attempts are exhausted", func(t *testing.T) {
+		resp := newResponse(http.StatusBadGateway, nil)
+
+		assert.AreEqual(t, policy.shouldRetry(context.Background(), 3, resp, nil), false)
+	})
+
+	t.Run("should not retry when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.AreEqual(t, policy.shouldRetry(ctx, 1, nil, context.Canceled), false)
+	})
+
+	t.Run("should use the custom retryable classification", func(t *testing.T) {
+		custom := RetryPolicy{
+			MaxAttempts: 3,
+			Retryable: func(statusCode int) bool {
+				return statusCode == http.StatusConflict
+			},
+		}
+
+		assert.AreEqual(
+			t,
+			custom.shouldRetry(context.Background(), 1, newResponse(http.StatusConflict, nil), nil),
+			true,
+		)
+		assert.AreEqual(
+			t,
+			custom.shouldRetry(context.Background(), 1, newResponse(http.StatusBadGateway, nil), nil),
+			false,
+		)
+	})
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Run("should double the delay on every attempt up to the max delay", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+
+		assert.AreEqual(t, policy.delay(1, nil), time.Second)
+		assert.AreEqual(t, policy.delay(2, nil), 2*time.Second)
+		assert.AreEqual(t, policy.delay(3, nil), 3*time.Second)
+	})
+
+	t.Run("should keep the jittered delay within bounds", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.5}
+
+		for range 100 {
+			delay := policy.delay(1, nil)
+			if delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+				t.Fatalf("delay out of bounds: %s", delay)
+			}
+		}
+	})
+
+	t.Run("should honour Retry-After in seconds", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: time.Second}
+		resp := newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"30"}})
+
+		assert.AreEqual(t, policy.delay(1, resp), 30*time.Second)
+	})
+
+	t.Run("should honour Retry-After as an HTTP date", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: time.Second}
+		date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+		resp := newResponse(http.StatusServiceUnavailable, http.Header{"Retry-After": {date}})
+
+		delay := policy.delay(1, resp)
+
+		if delay < 58*time.Second || delay > time.Minute {
+			t.Fatalf("unexpected delay: %s", delay)
+		}
+	})
+
+	t.Run("should ignore an invalid Retry-After", func(t *testing.T) {
+		policy := RetryPolicy{BaseDelay: time.Second}
+		resp := newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"soon"}})
+
+		assert.AreEqual(t, policy.delay(1, resp), time.Second)
+	})
+}
+
+func TestDoRetry(t *testing.T) {
+	noSleep := func(_ context.Context, _ time.Duration) error { return nil }
+
+	t.Run("should retry until the request succeeds", func(t *testing.T) {
+		calls := 0
+		mockClient := MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				calls++
+				body, _ := io.ReadAll(req.Body)
+				assert.AreEqual(t, string(body), "payload", "Expected payload on every attempt")
+				if calls < 3 {
+					return newResponse(http.StatusBadGateway, nil), nil
+				}
+				return newResponse(http.StatusOK, nil), nil
+			},
+		}
+		requester := &request{client: mockClient, sleep: noSleep, retry: RetryPolicy{MaxAttempts: 3}}
+		var attempts atomic.Int32
+
+		resp, _, err := requester.Do(
+			ContextWithAttemptCounter(context.Background(), &attempts),
+			WithMethod(http.MethodPost),
+			WithURL("https://example.com"),
+			WithPayload([]byte("payload")),
+		)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, resp.StatusCode, http.StatusOK)
+		assert.AreEqual(t, calls, 3)
+		assert.AreEqual(t, attempts.Load(), int32(3))
+	})
+
+	t.Run("should return the last response when attempts are exhausted", func(t *testing.T) {
+		calls := 0
+		mockClient := MockHTTPClient{
+			DoFunc: func(_ *http.Request) (*http.Response, error) {
+				calls++
+				return newResponse(http.StatusServiceUnavailable, nil), nil
+			},
+		}
+		requester := NewRequester(WithClient(mockClient), WithRetry(RetryPolicy{MaxAttempts: 2}))
+		requester.(*request).sleep = noSleep
+
+		resp, body, err := requester.Do(
+			context.Background(),
+			WithMethod(http.MethodGet),
+			WithURL("https://example.com"),
+		)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, resp.StatusCode, http.StatusServiceUnavailable)
+		assert.AreEqual(t, string(body), "Service Unavailable")
+		assert.AreEqual(t, calls, 2)
+	})
+
+	t.Run("should stop retrying when the context is canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mockClient := MockHTTPClient{
+			DoFunc: func(_ *http.Request) (*http.Response, error) {
+				cancel()
+				return nil, errors.New("connection reset by peer")
+			},
+		}
+		requester := &request{
+			client: mockClient,
+			sleep:  sleep,
+			retry:  RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour},
+		}
+		expectedErr := errors.New("error sending request: connection reset by peer")
+
+		_, _, err := requester.Do(ctx, WithMethod(http.MethodGet), WithURL("https://example.com"))
+
+		assert.AreEqualErrs(t, err, expectedErr)
+	})
+
+	t.Run("should return error when waiting to retry is interrupted", func(t *testing.T) {
+		mockClient := MockHTTPClient{
+			DoFunc: func(_ *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection reset by peer")
+			},
+		}
+		requester := &request{
+			client: mockClient,
+			sleep: func(_ context.Context, _ time.Duration) error {
+				return context.Canceled
+			},
+			retry: RetryPolicy{MaxAttempts: 3},
+		}
+		expectedErr := errors.New("error waiting to retry request: context canceled")
+
+		_, _, err := requester.Do(
+			context.Background(),
+			WithMethod(http.MethodGet),
+			WithURL("https://example.com"),
+		)
+
+		assert.AreEqualErrs(t, err, expectedErr)
+	})
+}
+
+func TestSleep(t *testing.T) {
+	t.Run("should return when the delay elapses", func(t *testing.T) {
+		err := sleep(context.Background(), time.Millisecond)
+
+		assert.IsNil(t, err)
+	})
+
+	t.Run("should return the context error when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := sleep(ctx, time.Hour)
+
+		assert.AreEqualErrs(t, err, context.Canceled)
+	})
+}
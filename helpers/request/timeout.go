@@ -0,0 +1,68 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrTimeout matches errors returned when a request did not complete in time.
+var ErrTimeout = errors.New("timeout")
+
+// TimeoutError is returned when an attempt exceeds its timeout,
+// either while connecting, during the TLS handshake or waiting for the response.
+// It allows telling a slow provider apart from a rejected message with errors.Is(err, ErrTimeout).
+type TimeoutError struct {
+	Err     error
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("timeout after %s: %v", e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("timeout: %v", e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// WithTimeout sets the timeout of every attempt of the request.
+// Zero means no timeout other than the context deadline.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *request) {
+		r.timeout = timeout
+	}
+}
+
+// NewHTTPClient creates an HTTP client whose transport enforces the timeout
+// while dialing, during the TLS handshake and while waiting for the response headers.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = timeout
+	transport.ResponseHeaderTimeout = timeout
+
+	return &http.Client{Transport: transport}
+}
+
+// asTimeout wraps err in a *TimeoutError when it was caused by a timeout.
+func asTimeout(err error, timeout time.Duration) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Err: err, Timeout: timeout}
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestTimeoutError(t *testing.T) {
+	t.Run("should format error with the timeout", func(t *testing.T) {
+		err := &TimeoutError{Err: context.DeadlineExceeded, Timeout: 5 * time.Second}
+
+		assert.AreEqualErrs(t, err, errors.New("timeout after 5s: context deadline exceeded"))
+	})
+
+	t.Run("should format error without timeout", func(t *testing.T) {
+		err := &TimeoutError{Err: context.DeadlineExceeded}
+
+		assert.AreEqualErrs(t, err, errors.New("timeout: context deadline exceeded"))
+	})
+
+	t.Run("should match ErrTimeout and the wrapped error", func(t *testing.T) {
+		err := error(&TimeoutError{Err: context.DeadlineExceeded})
+
+		assert.AreEqual(t, errors.Is(err, ErrTimeout), true)
+		assert.AreEqual(t, errors.Is(err, context.DeadlineExceeded), true)
+	})
+}
+
+func TestAsTimeout(t *testing.T) {
+	t.Run("should wrap deadline exceeded errors", func(t *testing.T) {
+		err := asTimeout(context.DeadlineExceeded, time.Second)
+
+		assert.AreEqual(t, errors.Is(err, ErrTimeout), true)
+	})
+
+	t.Run("should keep other errors", func(t *testing.T) {
+		original := errors.New("connection refused")
+
+		err := asTimeout(original, time.Second)
+
+		assert.AreEqual(t, err, original)
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("should set transport timeouts", func(t *testing.T) {
+		client := NewHTTPClient(3 * time.Second)
+
+		transport := client.Transport.(*http.Transport)
+		assert.AreEqual(t, transport.TLSHandshakeTimeout, 3*time.Second)
+		assert.AreEqual(t, transport.ResponseHeaderTimeout, 3*time.Second)
+		assert.IsNotNil(t, transport.DialContext)
+	})
+}
+
+func TestDoTimeout(t *testing.T) {
+	t.Run("should return a timeout error when the server is too slow", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		_, _, err := NewRequester().Do(
+			context.Background(),
+			WithMethod(http.MethodGet),
+			WithURL(server.URL),
+			WithTimeout(10*time.Millisecond),
+		)
+
+		var timeoutErr *TimeoutError
+		assert.AreEqual(t, errors.Is(err, ErrTimeout), true, "Expected a timeout error")
+		assert.AreEqual(t, errors.As(err, &timeoutErr), true)
+		assert.AreEqual(t, timeoutErr.Timeout, 10*time.Millisecond)
+	})
+
+	t.Run("should apply the timeout to every attempt", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				<-r.Context().Done()
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		resp, _, err := NewRequester(WithRetry(RetryPolicy{MaxAttempts: 2})).Do(
+			context.Background(),
+			WithMethod(http.MethodGet),
+			WithURL(server.URL),
+			WithTimeout(50*time.Millisecond),
+		)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, resp.StatusCode, http.StatusOK)
+		assert.AreEqual(t, calls.Load(), int32(2))
+	})
+}
@@ -0,0 +1,54 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// ProviderError is implemented by errors of messengers that carry
+// the details of the provider response, so they can be logged and inspected.
+// HTTPStatusCode returns the HTTP status code and ProviderCode the error code of the provider.
+type ProviderError interface {
+	error
+	HTTPStatusCode() int
+	ProviderCode() string
+}
+
+// WithLogger sets the logger used to report the delivery of every messenger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Nofy) {
+		s.logger = logger
+	}
+}
+
+// logResult reports the outcome of a messenger when a logger is set.
+func (s *Nofy) logResult(ctx context.Context, result Result) {
+	if s.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("messenger", result.Messenger),
+		slog.Int("index", result.Index),
+		slog.Int("attempts", result.Attempts),
+		slog.Duration("latency", result.Duration),
+	}
+
+	if result.OK() {
+		s.logger.LogAttrs(ctx, slog.LevelInfo, "notification delivered", attrs...)
+		return
+	}
+
+	var providerErr ProviderError
+	if errors.As(result.Err, &providerErr) {
+		attrs = append(
+			attrs,
+			slog.Int("status_code", providerErr.HTTPStatusCode()),
+			slog.String("error_code", providerErr.ProviderCode()),
+		)
+	}
+	attrs = append(attrs, slog.Any("error", result.Err))
+
+	s.logger.LogAttrs(ctx, slog.LevelError, "notification failed", attrs...)
+}
@@ -0,0 +1,101 @@
+package nofy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+type mockProviderError struct{}
+
+func (e *mockProviderError) Error() string        { return "error sending message: ratelimited" }
+func (e *mockProviderError) HTTPStatusCode() int  { return 429 }
+func (e *mockProviderError) ProviderCode() string { return "ratelimited" }
+
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	records := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("should log delivered notifications", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := New(
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithMessengers(&MockMessenger{name: "slack"}),
+		)
+
+		err := s.SendAll(context.Background())
+
+		records := decodeLogs(t, &buf)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(records), 1)
+		assert.AreEqual(t, records[0]["msg"], "notification delivered")
+		assert.AreEqual(t, records[0]["level"], "INFO")
+		assert.AreEqual(t, records[0]["messenger"], "slack")
+		assert.AreEqual(t, records[0]["attempts"], float64(1))
+	})
+
+	t.Run("should log failures with the provider details", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := New(
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithMessengers(&MockMessenger{name: "slack", sendFunc: func(_ context.Context) error {
+				return &mockProviderError{}
+			}}),
+		)
+
+		err := s.SendAll(context.Background())
+
+		records := decodeLogs(t, &buf)
+		assert.IsNotNil(t, err)
+		assert.AreEqual(t, len(records), 1)
+		assert.AreEqual(t, records[0]["msg"], "notification failed")
+		assert.AreEqual(t, records[0]["level"], "ERROR")
+		assert.AreEqual(t, records[0]["status_code"], float64(429))
+		assert.AreEqual(t, records[0]["error_code"], "ratelimited")
+		assert.AreEqual(t, records[0]["error"], "error sending message: ratelimited")
+	})
+
+	t.Run("should log failures without provider details", func(t *testing.T) {
+		var buf bytes.Buffer
+		s := New(
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithMessengers(&MockMessenger{name: "slack", sendFunc: func(_ context.Context) error {
+				return errors.New("connection refused")
+			}}),
+		)
+
+		_ = s.SendAll(context.Background())
+
+		records := decodeLogs(t, &buf)
+		_, hasStatusCode := records[0]["status_code"]
+		assert.AreEqual(t, hasStatusCode, false)
+		assert.AreEqual(t, records[0]["error"], "connection refused")
+	})
+
+	t.Run("should not log without logger", func(t *testing.T) {
+		s := New(WithMessengers(&MockMessenger{name: "slack"}))
+
+		err := s.SendAll(context.Background())
+
+		assert.IsNil(t, err)
+	})
+}
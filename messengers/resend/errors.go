@@ -0,0 +1,72 @@
+package resend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrRateLimited matches errors returned when Resend rate limits the request.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrValidation matches errors returned when Resend rejects the message as invalid.
+	ErrValidation = errors.New("validation error")
+)
+
+// Error is returned when Resend rejects a message.
+// StatusCode is the HTTP status code of the response.
+// Name and Message are decoded from the response body when available.
+// Body is the raw response body.
+// Doc: https://resend.com/docs/api-reference/errors
+type Error struct {
+	Name       string
+	Message    string
+	Body       []byte
+	StatusCode int
+}
+
+func newError(statusCode int, body []byte) *Error {
+	err := &Error{
+		StatusCode: statusCode,
+		Body:       body,
+	}
+
+	var response struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &response) == nil {
+		err.Name = response.Name
+		err.Message = response.Message
+	}
+
+	return err
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("error sending message: status-code: %d body: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatusCode returns the HTTP status code of the response.
+func (e *Error) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// ProviderCode returns the name of the error returned by Resend.
+func (e *Error) ProviderCode() string {
+	return e.Name
+}
+
+// Is reports whether the error matches target,
+// so errors.Is(err, ErrRateLimited) and errors.Is(err, ErrValidation) can be used.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	default:
+		return false
+	}
+}
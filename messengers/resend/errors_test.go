@@ -0,0 +1,53 @@
+package resend
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("should decode name and message from the body", func(t *testing.T) {
+		body := []byte(`{"name": "validation_error","statusCode": 422,"message": "Invalid to field."}`)
+
+		err := newError(http.StatusUnprocessableEntity, body)
+
+		assert.AreEqual(t, err.Name, "validation_error")
+		assert.AreEqual(t, err.Message, "Invalid to field.")
+		assert.AreEqualErrs(
+			t,
+			err,
+			errors.New("error sending message: status-code: 422 body: "+string(body)),
+		)
+	})
+
+	t.Run("should keep the raw body when it is not JSON", func(t *testing.T) {
+		err := newError(http.StatusBadGateway, []byte("bad gateway"))
+
+		assert.AreEqual(t, err.Name, "")
+		assert.AreEqual(t, string(err.Body), "bad gateway")
+	})
+
+	t.Run("should match ErrValidation on status code 422", func(t *testing.T) {
+		err := error(newError(http.StatusUnprocessableEntity, nil))
+
+		assert.AreEqual(t, errors.Is(err, ErrValidation), true)
+		assert.AreEqual(t, errors.Is(err, ErrRateLimited), false)
+	})
+
+	t.Run("should match ErrRateLimited on status code 429", func(t *testing.T) {
+		err := error(newError(http.StatusTooManyRequests, nil))
+
+		assert.AreEqual(t, errors.Is(err, ErrRateLimited), true)
+		assert.AreEqual(t, errors.Is(err, ErrValidation), false)
+	})
+
+	t.Run("should expose the provider details", func(t *testing.T) {
+		err := newError(http.StatusUnprocessableEntity, []byte(`{"name": "validation_error"}`))
+
+		assert.AreEqual(t, err.HTTPStatusCode(), http.StatusUnprocessableEntity)
+		assert.AreEqual(t, err.ProviderCode(), "validation_error")
+	})
+}
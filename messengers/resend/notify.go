@@ -0,0 +1,197 @@
+package resend
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+var htmlTemplate = template.Must(template.New("notification").Parse(
+	`{{define "content"}}` +
+		`{{range .Paragraphs}}<p>{{.}}</p>{{end}}` +
+		`{{if .Fields}}<table>{{range .Fields}}` +
+		`<tr><th align="left">{{.Name}}</th><td>{{.Value}}</td></tr>` +
+		`{{end}}</table>{{end}}` +
+		`{{if .Links}}<ul>{{range .Links}}<li><a href="{{.URL}}">{{.Text}}</a></li>{{end}}</ul>{{end}}` +
+		`{{end}}` +
+		`<h2>{{.Title}}</h2>{{template "content" .}}`,
+))
+
+var digestTemplate = template.Must(template.Must(htmlTemplate.Clone()).New("digest").Parse(
+	`<h2>{{.Title}}</h2>` +
+		`<ul>{{range .Items}}<li><strong>{{.Title}}</strong>{{template "content" .}}</li>{{end}}</ul>`,
+))
+
+// htmlContent is the data of the html templates for a notification.
+type htmlContent struct {
+	Title      string
+	Paragraphs []string
+	Fields     []nofy.Field
+	Links      []nofy.Link
+}
+
+// Renderer converts a notification into an email based on the configured message,
+// e.g. with templates.
+type Renderer func(base Message, n nofy.Notification) (Message, error)
+
+// Notify renders the notification into an email and sends it to the configured recipients.
+// The sender and recipients are taken from the configured message.
+func (r *Resend) Notify(ctx context.Context, n nofy.Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	render := Render
+	if r.Renderer != nil {
+		render = r.Renderer
+	}
+
+	message, err := render(r.Message, n)
+	if err != nil {
+		return fmt.Errorf("error rendering notification: %w", err)
+	}
+
+	return r.send(ctx, message)
+}
+
+// Render converts a notification into an email based on the given message.
+// The subject is the title prefixed by the severity when it is warning or above,
+// the HTML and Text contain the body, fields and links,
+// and the attachments are Base64 encoded.
+func Render(base Message, n nofy.Notification) (Message, error) {
+	message := base
+	message.Subject = renderSubject(n)
+	message.Text = renderText(n)
+
+	html, err := renderHTML(n)
+	if err != nil {
+		return Message{}, err
+	}
+	message.HTML = html
+
+	message.Attachments = make([]Attachment, 0, len(n.Attachments))
+	for _, attachment := range n.Attachments {
+		message.Attachments = append(message.Attachments, Attachment{
+			Filename: attachment.Filename,
+			Content:  base64.StdEncoding.EncodeToString(attachment.Content),
+		})
+	}
+
+	return message, nil
+}
+
+// NotifyDigest sends several notifications as a single email listing them.
+func (r *Resend) NotifyDigest(ctx context.Context, notifications []nofy.Notification) error {
+	if len(notifications) == 0 {
+		return fmt.Errorf("missing notifications")
+	}
+	for _, n := range notifications {
+		if err := n.Validate(); err != nil {
+			return err
+		}
+	}
+
+	message, err := RenderDigest(r.Message, notifications)
+	if err != nil {
+		return err
+	}
+
+	return r.send(ctx, message)
+}
+
+// RenderDigest converts several notifications into a single email based on the given message.
+// The subject counts the notifications, prefixed by the highest severity
+// when it is warning or above, the HTML and Text list every notification
+// and the attachments of all of them are included.
+func RenderDigest(base Message, notifications []nofy.Notification) (Message, error) {
+	digest := nofy.Notification{Title: fmt.Sprintf("%d notifications", len(notifications))}
+	items := make([]htmlContent, 0, len(notifications))
+	texts := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		digest.Severity = max(digest.Severity, n.Severity)
+		digest.Attachments = append(digest.Attachments, n.Attachments...)
+		items = append(items, newHTMLContent(n))
+		texts = append(texts, renderText(n))
+	}
+
+	message, err := Render(base, digest)
+	if err != nil {
+		return Message{}, err
+	}
+
+	var buf bytes.Buffer
+	err = digestTemplate.Execute(&buf, struct {
+		Title string
+		Items []htmlContent
+	}{
+		Title: digest.Title,
+		Items: items,
+	})
+	if err != nil {
+		return Message{}, fmt.Errorf("error rendering html: %w", err)
+	}
+	message.HTML = buf.String()
+	message.Text = digest.Title + "\n\n" + strings.Join(texts, "\n\n---\n\n")
+
+	return message, nil
+}
+
+func renderSubject(n nofy.Notification) string {
+	title := strings.TrimSpace(n.Title)
+	if title == "" {
+		title = strings.SplitN(strings.TrimSpace(n.Body), "\n", 2)[0]
+	}
+	if n.Severity >= nofy.SeverityWarning {
+		return fmt.Sprintf("[%s] %s", strings.ToUpper(n.Severity.String()), title)
+	}
+	return title
+}
+
+func renderHTML(n nofy.Notification) (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, newHTMLContent(n)); err != nil {
+		return "", fmt.Errorf("error rendering html: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func newHTMLContent(n nofy.Notification) htmlContent {
+	return htmlContent{
+		Title:      n.Title,
+		Paragraphs: paragraphs(n.Body),
+		Fields:     n.Fields,
+		Links:      n.Links,
+	}
+}
+
+func renderText(n nofy.Notification) string {
+	var b strings.Builder
+	if n.Title != "" {
+		b.WriteString(n.Title + "\n\n")
+	}
+	if n.Body != "" {
+		b.WriteString(n.Body + "\n\n")
+	}
+	for _, field := range n.Fields {
+		fmt.Fprintf(&b, "%s: %s\n", field.Name, field.Value)
+	}
+	for _, link := range n.Links {
+		fmt.Fprintf(&b, "%s: %s\n", link.Text, link.URL)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func paragraphs(body string) []string {
+	result := make([]string, 0)
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		if strings.TrimSpace(paragraph) != "" {
+			result = append(result, strings.TrimSpace(paragraph))
+		}
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package resend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+func TestNotify(t *testing.T) {
+	t.Run("should render and send notification to the configured recipients", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"id": "test-id"}`), nil
+			},
+		}
+		messenger := &Resend{
+			Token:   "test-token",
+			BaseURL: "https://api.resend.com",
+			Timeout: 5 * time.Second,
+			Message: Message{
+				From: "test-from",
+				To:   []string{"test-to"},
+			},
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{
+			Title:    "Disk full",
+			Body:     "Disk usage is at 95%",
+			Severity: nofy.SeverityError,
+		})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.From, "test-from", "Expected configured sender")
+		assert.AreEqual(t, sent.To, []string{"test-to"}, "Expected configured recipients")
+		assert.AreEqual(t, sent.Subject, "[ERROR] Disk full", "Expected rendered subject")
+	})
+
+	t.Run("should return error when notification is empty", func(t *testing.T) {
+		messenger := &Resend{requester: nil}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{})
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("should render notification into an email", func(t *testing.T) {
+		base := Message{From: "test-from", To: []string{"test-to"}}
+
+		message, err := Render(base, nofy.Notification{
+			Title:  "Deploy <finished>",
+			Body:   "First paragraph\n\nSecond paragraph",
+			Fields: []nofy.Field{{Name: "Version", Value: "1.2.3"}},
+			Links:  []nofy.Link{{Text: "Dashboard", URL: "https://example.com"}},
+			Attachments: []nofy.Attachment{
+				{Filename: "log.txt", Content: []byte("hello")},
+			},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, message.From, "test-from")
+		assert.AreEqual(t, message.Subject, "Deploy <finished>")
+		assert.AreEqual(
+			t,
+			message.HTML,
+			`<h2>Deploy &lt;finished&gt;</h2>`+
+				`<p>First paragraph</p><p>Second paragraph</p>`+
+				`<table><tr><th align="left">Version</th><td>1.2.3</td></tr></table>`+
+				`<ul><li><a href="https://example.com">Dashboard</a></li></ul>`,
+		)
+		assert.AreEqual(
+			t,
+			message.Text,
+			"Deploy <finished>\n\nFirst paragraph\n\nSecond paragraph\n\nVersion: 1.2.3\nDashboard: https://example.com",
+		)
+		assert.AreEqual(t, message.Attachments, []Attachment{{Filename: "log.txt", Content: "aGVsbG8="}})
+	})
+
+	t.Run("should use the first line of the body as subject when title is empty", func(t *testing.T) {
+		message, err := Render(Message{}, nofy.Notification{Body: "Disk full\nDetails"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, message.Subject, "Disk full")
+	})
+}
+
+func TestNotifyDigest(t *testing.T) {
+	t.Run("should send the notifications as a single email", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"id": "test-id"}`), nil
+			},
+		}
+		messenger := &Resend{
+			BaseURL: "https://api.resend.com",
+			Message: Message{
+				From: "test-from",
+				To:   []string{"test-to"},
+			},
+			requester: mockRequester,
+		}
+
+		err := messenger.NotifyDigest(context.TODO(), []nofy.Notification{
+			{Title: "Disk full"},
+			{Title: "Memory high", Severity: nofy.SeverityWarning},
+		})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.To, []string{"test-to"}, "Expected configured recipients")
+		assert.AreEqual(t, sent.Subject, "[WARNING] 2 notifications")
+	})
+
+	t.Run("should return error when there are no notifications", func(t *testing.T) {
+		messenger := &Resend{}
+
+		err := messenger.NotifyDigest(context.TODO(), nil)
+
+		assert.AreEqualErrs(t, err, errors.New("missing notifications"))
+	})
+}
+
+func TestRenderDigest(t *testing.T) {
+	t.Run("should list every notification", func(t *testing.T) {
+		message, err := RenderDigest(Message{From: "test-from"}, []nofy.Notification{
+			{Title: "Disk full", Body: "95%"},
+			{
+				Title:       "Memory high",
+				Fields:      []nofy.Field{{Name: "Host", Value: "api-1"}},
+				Attachments: []nofy.Attachment{{Filename: "top.txt", Content: []byte("top")}},
+			},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, message.From, "test-from")
+		assert.AreEqual(t, message.Subject, "2 notifications")
+		assert.AreEqual(t, message.HTML, `<h2>2 notifications</h2><ul>`+
+			`<li><strong>Disk full</strong><p>95%</p></li>`+
+			`<li><strong>Memory high</strong><table>`+
+			`<tr><th align="left">Host</th><td>api-1</td></tr></table></li></ul>`)
+		assert.AreEqual(t, message.Text, "2 notifications\n\n"+
+			"Disk full\n\n95%\n\n---\n\nMemory high\n\nHost: api-1")
+		assert.AreEqual(t, message.Attachments, []Attachment{{Filename: "top.txt", Content: "dG9w"}})
+	})
+}
+
+func TestRenderer(t *testing.T) {
+	t.Run("should render notifications with the renderer", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"id": "test-id"}`), nil
+			},
+		}
+		messenger := &Resend{
+			Message: Message{From: "test-from", To: []string{"test-to"}},
+			Renderer: func(base Message, n nofy.Notification) (Message, error) {
+				base.Subject = "Alert: " + n.Title
+				base.HTML = "<p>custom</p>"
+				return base, nil
+			},
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.From, "test-from")
+		assert.AreEqual(t, sent.Subject, "Alert: Disk full")
+		assert.AreEqual(t, sent.HTML, "<p>custom</p>")
+	})
+
+	t.Run("should return error when rendering fails", func(t *testing.T) {
+		messenger := &Resend{
+			Renderer: func(_ Message, _ nofy.Notification) (Message, error) {
+				return Message{}, errors.New("missing template")
+			},
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		assert.AreEqualErrs(t, err, errors.New("error rendering notification: missing template"))
+	})
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
 	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
@@ -15,6 +19,8 @@ const Timeout = 5000
 
 var MarshalFunc = json.Marshal
 
+// HTTPClient is the client used to send requests to Resend.
+// *http.Client satisfies this interface.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -22,10 +28,17 @@ type HTTPClient interface {
 // Resend is a client to send messages to Resend.
 type Resend struct {
 	requester request.Requester
-	URL       string
+	Client    HTTPClient
+	BaseURL   string
 	Token     string
 	Message   Message
 	Timeout   time.Duration
+	Logger    *slog.Logger
+	Limiter   *ratelimit.Limiter
+	Renderer  Renderer
+	Retry     request.RetryPolicy
+	// LimitByRecipient keys the rate limiter by the recipients of each message.
+	LimitByRecipient bool
 }
 
 // Message is the message to send to Resend.
@@ -34,13 +47,23 @@ type Resend struct {
 // CC is the email addresses of the CC recipients.
 // Subject is the subject of the email (required).
 // HTML is the HTML content of the email.
+// Text is the plain text content of the email.
+// Attachments are the files attached to the email.
 type Message struct {
-	From    string   `json:"from"`
-	CC      string   `json:"cc"`
-	Subject string   `json:"subject"`
-	HTML    string   `json:"html"`
-	Text    string   `json:"text"`
-	To      []string `json:"to"`
+	From        string       `json:"from"`
+	CC          string       `json:"cc"`
+	Subject     string       `json:"subject"`
+	HTML        string       `json:"html"`
+	Text        string       `json:"text"`
+	To          []string     `json:"to"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a file attached to the email.
+// Content is the Base64 encoded content of the file.
+type Attachment struct {
+	Filename string `json:"filename"`
+	Content  string `json:"content"`
 }
 
 type Option func(*Resend)
@@ -48,7 +71,7 @@ type Option func(*Resend)
 // NewResendMessenger creates a new Resend client.
 func NewResendMessenger(options ...Option) (*Resend, error) {
 	resend := &Resend{
-		URL:     "https://api.resend.com/emails",
+		BaseURL: "https://api.resend.com",
 		Timeout: Timeout * time.Millisecond,
 	}
 
@@ -61,7 +84,15 @@ func NewResendMessenger(options ...Option) (*Resend, error) {
 		return nil, err
 	}
 
-	resend.requester = request.NewRequester()
+	if resend.Client == nil {
+		resend.Client = request.NewHTTPClient(resend.Timeout)
+	}
+
+	resend.requester = request.NewRequester(
+		request.WithClient(resend.Client),
+		request.WithRetry(resend.Retry),
+		request.WithLogger(resend.Logger),
+	)
 
 	return resend, nil
 }
@@ -71,20 +102,26 @@ func validate(resend *Resend) error {
 	if strings.TrimSpace(resend.Token) == "" {
 		return fmt.Errorf("missing token")
 	}
+	baseURL, err := url.Parse(resend.BaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return fmt.Errorf("invalid base url")
+	}
 	if strings.TrimSpace(resend.Message.From) == "" {
 		return fmt.Errorf("missing from")
 	}
 	if len(resend.Message.To) == 0 {
 		return fmt.Errorf("missing to")
 	}
-	if strings.TrimSpace(resend.Message.Subject) == "" {
-		return fmt.Errorf("missing subject")
-	}
 
 	return nil
 }
 
-// WithURL sets the URL for the Resend client.
+// Name returns the name of the messenger.
+func (r *Resend) Name() string {
+	return "resend"
+}
+
+// WithToken sets the Token for the Resend client.
 func WithToken(token string) Option {
 	return func(r *Resend) {
 		r.Token = token
@@ -98,6 +135,63 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used by the Resend client,
+// e.g. to route requests through a proxy or to use custom TLS roots.
+// The timeout is still enforced on every request.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(r *Resend) {
+		r.Client = client
+	}
+}
+
+// WithBaseURL sets the base URL of the Resend API (default: https://api.resend.com).
+func WithBaseURL(baseURL string) Option {
+	return func(r *Resend) {
+		r.BaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRetry sets the retry policy for the Resend client.
+// By default a failed message is not retried.
+func WithRetry(policy request.RetryPolicy) Option {
+	return func(r *Resend) {
+		r.Retry = policy
+	}
+}
+
+// WithLogger sets the logger used to report the requests of the Resend client.
+// The token is redacted from the logs.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Resend) {
+		r.Logger = logger
+	}
+}
+
+// WithRateLimit sets the rate limiter of the Resend client,
+// shared by every message unless keyed by recipient with WithRateLimitByRecipient.
+// Depending on the limiter, messages over the limit wait or fail with a *ratelimit.LimitError.
+func WithRateLimit(limiter *ratelimit.Limiter) Option {
+	return func(r *Resend) {
+		r.Limiter = limiter
+	}
+}
+
+// WithRateLimitByRecipient keys the rate limiter by the recipients of each message,
+// so a burst to one recipient does not delay the messages to the others.
+func WithRateLimitByRecipient() Option {
+	return func(r *Resend) {
+		r.LimitByRecipient = true
+	}
+}
+
+// WithRenderer sets how notifications are converted into emails (default: Render).
+// Digests are always rendered with RenderDigest.
+func WithRenderer(renderer Renderer) Option {
+	return func(r *Resend) {
+		r.Renderer = renderer
+	}
+}
+
 // WithMessage sets the Message for the Resend client.
 func WithMessage(message *Message) Option {
 	return func(r *Resend) {
@@ -107,29 +201,53 @@ func WithMessage(message *Message) Option {
 
 // Send sends a message using the Resend client.
 func (r *Resend) Send(ctx context.Context) error {
-	msg, err := MarshalFunc(r.Message)
+	if strings.TrimSpace(r.Message.Subject) == "" {
+		return fmt.Errorf("missing subject")
+	}
+
+	return r.send(ctx, r.Message)
+}
+
+// send sends the given message using the Resend client.
+func (r *Resend) send(ctx context.Context, message Message) error {
+	msg, err := MarshalFunc(message)
 	if err != nil {
 		return fmt.Errorf("error marshaling message: %w", err)
 	}
 
-	HTTPClient := http.DefaultClient
+	if r.Limiter != nil {
+		if err := r.Limiter.Wait(ctx, r.limitKey(message)); err != nil {
+			return err
+		}
+	}
 
 	res, body, err := r.requester.Do(ctx,
 		request.WithMethod(http.MethodPost),
-		request.WithURL(r.URL),
+		request.WithURL(r.BaseURL+"/emails"),
 		request.WithHeader("Authorization", "Bearer "+r.Token),
 		request.WithHeader("Content-Type", "application/json"),
 		request.WithHeader("Accept", "application/json"),
-		request.WithClient(HTTPClient),
 		request.WithPayload(msg),
+		request.WithTimeout(r.Timeout),
 	)
 	if err != nil {
 		return fmt.Errorf("error sending message: %w", err)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error sending message: status-code: %d body: %s", res.StatusCode, body)
+		return newError(res.StatusCode, body)
 	}
 
 	return nil
 }
+
+// limitKey returns the key of the rate limiter for the message.
+func (r *Resend) limitKey(message Message) string {
+	if !r.LimitByRecipient {
+		return "resend"
+	}
+
+	recipients := slices.Clone(message.To)
+	slices.Sort(recipients)
+	return "resend:" + strings.Join(recipients, ",")
+}
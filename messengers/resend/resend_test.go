@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
 	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
@@ -92,23 +95,129 @@ func TestNewResendMessenger(t *testing.T) {
 		)
 	})
 
-	t.Run("should return error when subject is missing", func(t *testing.T) {
+	t.Run("should return error when base URL is invalid", func(t *testing.T) {
 		_, err := NewResendMessenger(
 			WithToken("test-token"),
-			WithTimeout(5*time.Second),
+			WithBaseURL("://invalid"),
 			WithMessage(
 				&Message{
 					From: "test-from",
 					To:   []string{"test-to"},
-					HTML: "<p> Text Html</p>",
 				}),
 		)
 
 		assert.AreEqualErrs(
 			t,
 			err,
-			errors.New("missing subject"),
-			"Expected missing Resend Subject error",
+			errors.New("invalid base url"),
+			"Expected invalid base URL error",
+		)
+	})
+
+	t.Run("should send message through the given HTTP client and base URL", func(t *testing.T) {
+		var got *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			_, _ = w.Write([]byte(`{"id": "test-id"}`))
+		}))
+		defer server.Close()
+		messenger, err := NewResendMessenger(
+			WithToken("test-token"),
+			WithBaseURL(server.URL),
+			WithHTTPClient(server.Client()),
+			WithMessage(
+				&Message{
+					From:    "test-from",
+					To:      []string{"test-to"},
+					Subject: "test-subject",
+				}),
+		)
+		assert.IsNil(t, err)
+
+		err = messenger.Send(context.TODO())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.URL.Path, "/emails")
+		assert.AreEqual(t, got.Header.Get("Authorization"), "Bearer test-token")
+	})
+
+	t.Run("should create Resend messenger without subject", func(t *testing.T) {
+		messenger, err := NewResendMessenger(
+			WithToken("test-token"),
+			WithTimeout(5*time.Second),
+			WithMessage(
+				&Message{
+					From: "test-from",
+					To:   []string{"test-to"},
+				}),
+		)
+
+		assert.IsNil(t, err)
+		assert.IsNotNil(t, messenger)
+	})
+}
+
+func TestResendOptions(t *testing.T) {
+	t.Run("should set rate limiter correctly with WithRateLimit option", func(t *testing.T) {
+		resend := &Resend{}
+		limiter := ratelimit.New(time.Second, 2)
+		WithRateLimit(limiter)(resend)
+
+		assert.AreEqual(
+			t,
+			resend.Limiter,
+			limiter,
+			"Expected rate limiter to be set",
+		)
+	})
+
+	t.Run("should set logger correctly with WithLogger option", func(t *testing.T) {
+		resend := &Resend{}
+		logger := slog.Default()
+		WithLogger(logger)(resend)
+
+		assert.AreEqual(
+			t,
+			resend.Logger,
+			logger,
+			"Expected logger to be set",
+		)
+	})
+
+	t.Run("should set HTTP client correctly with WithHTTPClient option", func(t *testing.T) {
+		resend := &Resend{}
+		client := &http.Client{}
+		WithHTTPClient(client)(resend)
+
+		assert.AreEqual(
+			t,
+			resend.Client,
+			HTTPClient(client),
+			"Expected HTTP client to be set",
+		)
+	})
+
+	t.Run("should set base URL correctly with WithBaseURL option", func(t *testing.T) {
+		resend := &Resend{}
+		WithBaseURL("http://localhost:8080/")(resend)
+
+		assert.AreEqual(
+			t,
+			resend.BaseURL,
+			"http://localhost:8080",
+			"Expected base URL without trailing slash",
+		)
+	})
+
+	t.Run("should set retry policy correctly with WithRetry option", func(t *testing.T) {
+		resend := &Resend{}
+		WithRetry(request.DefaultRetryPolicy())(resend)
+
+		assert.AreEqual(
+			t,
+			resend.Retry.MaxAttempts,
+			3,
+			"Expected retry policy to be set",
 		)
 	})
 }
@@ -117,6 +226,12 @@ func TestSend(t *testing.T) {
 	t.Run("should send message successfully", func(t *testing.T) {
 		mockRequester := &request.MockRequester{
 			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				assert.AreEqual(
+					t,
+					request.NewMockRequest(options...).Timeout,
+					5*time.Second,
+					"Expected timeout to be applied",
+				)
 				return &http.Response{
 					StatusCode: http.StatusOK,
 				}, []byte(`{"ok": true}`), nil
@@ -131,7 +246,7 @@ func TestSend(t *testing.T) {
 
 		messenger := &Resend{
 			Token:     "test-token",
-			URL:       "https://api.sendgrid.com/v3/mail/send",
+			BaseURL:   "https://api.resend.com",
 			Timeout:   5 * time.Second,
 			Message:   message,
 			requester: mockRequester,
@@ -142,6 +257,29 @@ func TestSend(t *testing.T) {
 		assert.IsNil(t, err)
 	})
 
+	t.Run("should return error when subject is missing", func(t *testing.T) {
+		messenger := &Resend{
+			Token:   "test-token",
+			BaseURL: "https://api.resend.com",
+			Timeout: 5 * time.Second,
+			Message: Message{
+				From: "test-from",
+				To:   []string{"test-to"},
+				HTML: "<p> Text Html</p>",
+			},
+			requester: nil,
+		}
+
+		err := messenger.Send(context.TODO())
+
+		assert.AreEqualErrs(
+			t,
+			err,
+			errors.New("missing subject"),
+			"Expected missing Resend Subject error",
+		)
+	})
+
 	t.Run("should return error when marshalling message fails", func(t *testing.T) {
 		MarshalFunc = func(_ interface{}) ([]byte, error) {
 			return nil, errors.New("invalid payload")
@@ -157,7 +295,7 @@ func TestSend(t *testing.T) {
 		messenger := &Resend{
 			Token:     "test-token",
 			Timeout:   5 * time.Second,
-			URL:       "https://api.resend.com/emails",
+			BaseURL:   "https://api.resend.com",
 			Message:   message,
 			requester: nil,
 		}
@@ -174,6 +312,51 @@ func TestSend(t *testing.T) {
 		MarshalFunc = json.Marshal
 	})
 
+	t.Run("should return error when over the rate limit", func(t *testing.T) {
+		messenger := &Resend{
+			Token:   "test-token",
+			BaseURL: "https://api.resend.com",
+			Message: Message{
+				From:    "test-from",
+				To:      []string{"test-to"},
+				Subject: "test-subject",
+			},
+			Limiter:   ratelimit.New(time.Hour, 1, ratelimit.WithFailFast()),
+			requester: nil,
+		}
+		_ = messenger.Limiter.Wait(context.TODO(), "resend")
+
+		err := messenger.Send(context.TODO())
+
+		assert.AreEqual(t, errors.Is(err, ratelimit.ErrLimited), true, "Expected rate limit error")
+	})
+
+	t.Run("should key the rate limit by recipient", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{}`), nil
+			},
+		}
+		messenger := &Resend{
+			Limiter:          ratelimit.New(time.Hour, 1, ratelimit.WithFailFast()),
+			LimitByRecipient: true,
+			requester:        mockRequester,
+		}
+		message := Message{From: "test-from", Subject: "test-subject"}
+		alice := message
+		alice.To = []string{"alice@example.com"}
+		bob := message
+		bob.To = []string{"bob@example.com"}
+
+		first := messenger.send(context.TODO(), alice)
+		second := messenger.send(context.TODO(), bob)
+		third := messenger.send(context.TODO(), alice)
+
+		assert.IsNil(t, first)
+		assert.IsNil(t, second)
+		assert.AreEqual(t, errors.Is(third, ratelimit.ErrLimited), true, "Expected rate limit error")
+	})
+
 	t.Run("should return error when request fails", func(t *testing.T) {
 		mockRequester := &request.MockRequester{
 			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
@@ -189,7 +372,7 @@ func TestSend(t *testing.T) {
 
 		messenger := &Resend{
 			Token:     "test-token",
-			URL:       "https://api.resend.com/emails",
+			BaseURL:   "https://api.resend.com",
 			Timeout:   5 * time.Second,
 			Message:   message,
 			requester: mockRequester,
@@ -225,7 +408,7 @@ func TestSend(t *testing.T) {
 
 		messenger := &Resend{
 			Token:     "test-token",
-			URL:       "https://api.resend.com/emails",
+			BaseURL:   "https://api.resend.com",
 			Timeout:   5 * time.Second,
 			Message:   message,
 			requester: mockRequester,
@@ -0,0 +1,435 @@
+// Package blockkit provides typed Slack Block Kit blocks.
+// Blocks are validated against the limits documented by Slack and marshal
+// to the payload expected by slack.Message.Content.
+// Doc: https://api.slack.com/reference/block-kit/blocks
+package blockkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Slack documented limits.
+const (
+	maxBlocks            = 50
+	maxBlockIDLength     = 255
+	maxSectionLength     = 3000
+	maxFieldLength       = 2000
+	maxFields            = 10
+	maxHeaderLength      = 150
+	maxContextElements   = 10
+	maxActionsElements   = 25
+	maxImageTitleLength  = 2000
+	maxAltTextLength     = 2000
+	maxURLLength         = 3000
+	maxLabelLength       = 2000
+	maxHintLength        = 2000
+	maxButtonTextLength  = 75
+	maxValueLength       = 2000
+	maxPlaceholderLength = 150
+	maxOptionTextLength  = 75
+	maxOptionValueLength = 150
+	maxSelectOptions     = 100
+	minOverflowOptions   = 1
+	maxOverflowOptions   = 5
+	maxCheckboxOptions   = 10
+	maxInputLength       = 3000
+	maxOptionGroups      = 100
+	maxGroupLabelLength  = 75
+	maxConfirmTitle      = 100
+	maxConfirmTextLength = 300
+	maxConfirmButton     = 30
+)
+
+// Block is a Block Kit block.
+type Block interface {
+	BlockType() string
+	Validate() error
+}
+
+// Blocks is the list of blocks of a message.
+type Blocks []Block
+
+// Validate checks every block and the number of blocks.
+func (b Blocks) Validate() error {
+	if len(b) > maxBlocks {
+		return fmt.Errorf("message exceeds %d blocks", maxBlocks)
+	}
+
+	for i, block := range b {
+		if block == nil {
+			return fmt.Errorf("block %d: missing block", i)
+		}
+		if err := block.Validate(); err != nil {
+			return fmt.Errorf("block %d: %s: %w", i, block.BlockType(), err)
+		}
+	}
+	return nil
+}
+
+// Maps validates the blocks and converts them into the content of a slack.Message.
+func (b Blocks) Maps() ([]map[string]any, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling blocks: %w", err)
+	}
+
+	var maps []map[string]any
+	if err := json.Unmarshal(data, &maps); err != nil {
+		return nil, fmt.Errorf("error unmarshalling blocks: %w", err)
+	}
+	return maps, nil
+}
+
+func validateBlockID(blockID string) error {
+	return validateLength("block id", blockID, maxBlockIDLength)
+}
+
+// Section displays text, fields and an optional accessory element.
+// Doc: https://api.slack.com/reference/block-kit/blocks#section
+type Section struct {
+	Text      *Text   `json:"text,omitempty"`
+	Accessory Element `json:"accessory,omitempty"`
+	BlockID   string  `json:"block_id,omitempty"`
+	Fields    []*Text `json:"fields,omitempty"`
+	Expand    bool    `json:"expand,omitempty"`
+}
+
+// BlockType returns section.
+func (Section) BlockType() string { return "section" }
+
+// Validate checks the limits of the section.
+func (s Section) Validate() error {
+	if s.Text == nil && len(s.Fields) == 0 {
+		return errors.New("missing text or fields")
+	}
+	if err := validateOptionalText("text", s.Text, maxSectionLength, false); err != nil {
+		return err
+	}
+	if len(s.Fields) > maxFields {
+		return fmt.Errorf("fields exceed %d", maxFields)
+	}
+	for _, field := range s.Fields {
+		if err := validateText("field", field, maxFieldLength, false); err != nil {
+			return err
+		}
+	}
+	if s.Accessory != nil {
+		if err := s.Accessory.Validate(); err != nil {
+			return fmt.Errorf("accessory: %w", err)
+		}
+	}
+	return validateBlockID(s.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (s Section) MarshalJSON() ([]byte, error) {
+	type alias Section
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.BlockType(), alias(s)})
+}
+
+// UnmarshalJSON decodes the accessory according to its type.
+func (s *Section) UnmarshalJSON(data []byte) error {
+	type alias Section
+	raw := struct {
+		*alias
+		Accessory json.RawMessage `json:"accessory"`
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Accessory) == 0 {
+		return nil
+	}
+
+	accessory, err := decodeElement(raw.Accessory)
+	if err != nil {
+		return err
+	}
+	s.Accessory = accessory
+	return nil
+}
+
+// Header displays plain text in a larger, bold font.
+// Doc: https://api.slack.com/reference/block-kit/blocks#header
+type Header struct {
+	Text    *Text  `json:"text"`
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// NewHeader creates a header with the plain text.
+func NewHeader(text string) *Header {
+	return &Header{Text: PlainText(text)}
+}
+
+// BlockType returns header.
+func (Header) BlockType() string { return "header" }
+
+// Validate checks the limits of the header.
+func (h Header) Validate() error {
+	if err := validateText("text", h.Text, maxHeaderLength, true); err != nil {
+		return err
+	}
+	return validateBlockID(h.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (h Header) MarshalJSON() ([]byte, error) {
+	type alias Header
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{h.BlockType(), alias(h)})
+}
+
+// Divider is a horizontal line between blocks.
+// Doc: https://api.slack.com/reference/block-kit/blocks#divider
+type Divider struct {
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// BlockType returns divider.
+func (Divider) BlockType() string { return "divider" }
+
+// Validate checks the block id.
+func (d Divider) Validate() error {
+	return validateBlockID(d.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (d Divider) MarshalJSON() ([]byte, error) {
+	type alias Divider
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{d.BlockType(), alias(d)})
+}
+
+// Context displays small texts and images.
+// Doc: https://api.slack.com/reference/block-kit/blocks#context
+type Context struct {
+	BlockID  string    `json:"block_id,omitempty"`
+	Elements []Element `json:"elements"`
+}
+
+// BlockType returns context.
+func (Context) BlockType() string { return "context" }
+
+// Validate checks that the context has between 1 and 10 texts or images.
+func (c Context) Validate() error {
+	if len(c.Elements) == 0 || len(c.Elements) > maxContextElements {
+		return fmt.Errorf("must have between 1 and %d elements", maxContextElements)
+	}
+	for _, element := range c.Elements {
+		switch element := element.(type) {
+		case *Text:
+			if err := validateText("text", element, maxSectionLength, false); err != nil {
+				return err
+			}
+		case Text:
+			if err := validateText("text", &element, maxSectionLength, false); err != nil {
+				return err
+			}
+		case *ImageElement, ImageElement:
+			if err := element.Validate(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid element %T", element)
+		}
+	}
+	return validateBlockID(c.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (c Context) MarshalJSON() ([]byte, error) {
+	type alias Context
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{c.BlockType(), alias(c)})
+}
+
+// UnmarshalJSON decodes the elements according to their types.
+func (c *Context) UnmarshalJSON(data []byte) error {
+	type alias Context
+	raw := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	elements, err := decodeElements(raw.Elements)
+	if err != nil {
+		return err
+	}
+	c.Elements = elements
+	return nil
+}
+
+// Actions holds interactive elements.
+// Doc: https://api.slack.com/reference/block-kit/blocks#actions
+type Actions struct {
+	BlockID  string    `json:"block_id,omitempty"`
+	Elements []Element `json:"elements"`
+}
+
+// BlockType returns actions.
+func (Actions) BlockType() string { return "actions" }
+
+// Validate checks that the block has between 1 and 25 valid elements.
+func (a Actions) Validate() error {
+	if len(a.Elements) == 0 || len(a.Elements) > maxActionsElements {
+		return fmt.Errorf("must have between 1 and %d elements", maxActionsElements)
+	}
+	for _, element := range a.Elements {
+		if element == nil {
+			return errors.New("missing element")
+		}
+		if err := element.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", element.ElementType(), err)
+		}
+	}
+	return validateBlockID(a.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (a Actions) MarshalJSON() ([]byte, error) {
+	type alias Actions
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{a.BlockType(), alias(a)})
+}
+
+// UnmarshalJSON decodes the elements according to their types.
+func (a *Actions) UnmarshalJSON(data []byte) error {
+	type alias Actions
+	raw := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	elements, err := decodeElements(raw.Elements)
+	if err != nil {
+		return err
+	}
+	a.Elements = elements
+	return nil
+}
+
+// Image displays an image.
+// Doc: https://api.slack.com/reference/block-kit/blocks#image
+type Image struct {
+	Title    *Text  `json:"title,omitempty"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+	BlockID  string `json:"block_id,omitempty"`
+}
+
+// BlockType returns image.
+func (Image) BlockType() string { return "image" }
+
+// Validate checks the limits of the image.
+func (i Image) Validate() error {
+	if i.ImageURL == "" {
+		return errors.New("missing image url")
+	}
+	if err := validateLength("image url", i.ImageURL, maxURLLength); err != nil {
+		return err
+	}
+	if i.AltText == "" {
+		return errors.New("missing alt text")
+	}
+	if err := validateLength("alt text", i.AltText, maxAltTextLength); err != nil {
+		return err
+	}
+	if err := validateOptionalText("title", i.Title, maxImageTitleLength, true); err != nil {
+		return err
+	}
+	return validateBlockID(i.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (i Image) MarshalJSON() ([]byte, error) {
+	type alias Image
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{i.BlockType(), alias(i)})
+}
+
+// Input collects information from users in modals, messages and home tabs.
+// Doc: https://api.slack.com/reference/block-kit/blocks#input
+type Input struct {
+	Label          *Text   `json:"label"`
+	Element        Element `json:"element"`
+	Hint           *Text   `json:"hint,omitempty"`
+	BlockID        string  `json:"block_id,omitempty"`
+	Optional       bool    `json:"optional,omitempty"`
+	DispatchAction bool    `json:"dispatch_action,omitempty"`
+}
+
+// BlockType returns input.
+func (Input) BlockType() string { return "input" }
+
+// Validate checks the limits of the input.
+func (i Input) Validate() error {
+	if err := validateText("label", i.Label, maxLabelLength, true); err != nil {
+		return err
+	}
+	if i.Element == nil {
+		return errors.New("missing element")
+	}
+	if err := i.Element.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", i.Element.ElementType(), err)
+	}
+	if err := validateOptionalText("hint", i.Hint, maxHintLength, true); err != nil {
+		return err
+	}
+	return validateBlockID(i.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (i Input) MarshalJSON() ([]byte, error) {
+	type alias Input
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{i.BlockType(), alias(i)})
+}
+
+// UnmarshalJSON decodes the element according to its type.
+func (i *Input) UnmarshalJSON(data []byte) error {
+	type alias Input
+	raw := struct {
+		*alias
+		Element json.RawMessage `json:"element"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Element) == 0 {
+		return nil
+	}
+
+	element, err := decodeElement(raw.Element)
+	if err != nil {
+		return err
+	}
+	i.Element = element
+	return nil
+}
@@ -0,0 +1,150 @@
+package blockkit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestBlocksValidate(t *testing.T) {
+	t.Run("should report a misspelled text type", func(t *testing.T) {
+		blocks := Blocks{&Section{Text: &Text{Type: "mrkdwm", Text: "*Deploy* failed"}}}
+
+		err := blocks.Validate()
+
+		assert.AreEqual(t, err.Error(), `block 0: section: text: invalid text type "mrkdwm"`)
+	})
+
+	t.Run("should limit the number of blocks", func(t *testing.T) {
+		blocks := make(Blocks, 51)
+		for i := range blocks {
+			blocks[i] = &Divider{}
+		}
+
+		err := blocks.Validate()
+
+		assert.AreEqual(t, err.Error(), "message exceeds 50 blocks")
+	})
+
+	t.Run("should limit the length of section texts", func(t *testing.T) {
+		blocks := Blocks{
+			&Section{Text: Markdown(strings.Repeat("a", 3000))},
+			&Section{Text: Markdown(strings.Repeat("é", 3001))},
+		}
+
+		err := blocks.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 1: section: text exceeds 3000 characters")
+	})
+
+	t.Run("should limit the number of fields", func(t *testing.T) {
+		fields := make([]*Text, 11)
+		for i := range fields {
+			fields[i] = Markdown("*Service*\napi")
+		}
+
+		err := Blocks{&Section{Fields: fields}}.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 0: section: fields exceed 10")
+	})
+
+	t.Run("should require plain text headers", func(t *testing.T) {
+		err := Blocks{&Header{Text: Markdown("*Deploy*")}}.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 0: header: text: must be plain_text")
+	})
+
+	t.Run("should only allow texts and images in context blocks", func(t *testing.T) {
+		err := Blocks{&Context{Elements: []Element{
+			Markdown("Triggered by <@U123>"),
+			&Button{Text: PlainText("Retry")},
+		}}}.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 0: context: invalid element *blockkit.Button")
+	})
+
+	t.Run("should validate elements of actions", func(t *testing.T) {
+		err := Blocks{&Actions{Elements: []Element{
+			&Button{Text: PlainText("Retry"), Style: "warning"},
+		}}}.Validate()
+
+		assert.AreEqual(t, err.Error(), `block 0: actions: button: invalid style "warning"`)
+	})
+
+	t.Run("should validate rich text elements", func(t *testing.T) {
+		err := Blocks{&RichText{Elements: []RichTextElement{
+			&RichTextList{Style: "dashed", Elements: []*RichTextSection{}},
+		}}}.Validate()
+
+		assert.AreEqual(t, err.Error(), `block 0: rich_text: rich_text_list: invalid style "dashed"`)
+	})
+
+	t.Run("should require the element of inputs", func(t *testing.T) {
+		err := Blocks{&Input{Label: PlainText("Reason")}}.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 0: input: missing element")
+	})
+
+	t.Run("should require the alt text of images", func(t *testing.T) {
+		err := Blocks{&Image{ImageURL: "https://example.com/graph.png"}}.Validate()
+
+		assert.AreEqual(t, err.Error(), "block 0: image: missing alt text")
+	})
+}
+
+func TestBlocksMaps(t *testing.T) {
+	t.Run("should convert blocks into message content", func(t *testing.T) {
+		blocks := Blocks{
+			NewHeader("Deploy failed"),
+			&Section{
+				Text:   Markdown("*api* v1.2.3"),
+				Fields: []*Text{Markdown("*Env*\nprod")},
+				Accessory: &Button{
+					Text:     PlainText("Logs"),
+					URL:      "https://example.com/logs",
+					ActionID: "logs",
+				},
+			},
+			&Divider{},
+			&Context{Elements: []Element{Markdown("Triggered by <@U123>")}},
+		}
+
+		maps, err := blocks.Maps()
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, maps, []map[string]any{
+			{
+				"type": "header",
+				"text": map[string]any{"type": "plain_text", "text": "Deploy failed"},
+			},
+			{
+				"type": "section",
+				"text": map[string]any{"type": "mrkdwn", "text": "*api* v1.2.3"},
+				"fields": []any{
+					map[string]any{"type": "mrkdwn", "text": "*Env*\nprod"},
+				},
+				"accessory": map[string]any{
+					"type":      "button",
+					"text":      map[string]any{"type": "plain_text", "text": "Logs"},
+					"url":       "https://example.com/logs",
+					"action_id": "logs",
+				},
+			},
+			{"type": "divider"},
+			{
+				"type": "context",
+				"elements": []any{
+					map[string]any{"type": "mrkdwn", "text": "Triggered by <@U123>"},
+				},
+			},
+		})
+	})
+
+	t.Run("should return an error when the blocks are invalid", func(t *testing.T) {
+		maps, err := Blocks{&Section{}}.Maps()
+
+		assert.IsNil(t, maps)
+		assert.AreEqual(t, err.Error(), "block 0: section: missing text or fields")
+	})
+}
@@ -0,0 +1,565 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Types of text objects.
+const (
+	TypePlainText = "plain_text"
+	TypeMarkdown  = "mrkdwn"
+)
+
+// Element is an element of a block: a text, an image or an interactive element.
+// Doc: https://api.slack.com/reference/block-kit/block-elements
+type Element interface {
+	ElementType() string
+	Validate() error
+}
+
+// Text is a text object, either plain text or markdown.
+// Emoji is a pointer because Slack renders the emoji of plain text when it is unset,
+// so an explicit false must be kept.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#text
+type Text struct {
+	Emoji    *bool  `json:"emoji,omitempty"`
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Verbatim bool   `json:"verbatim,omitempty"`
+}
+
+// PlainText creates a plain text object.
+func PlainText(text string) *Text {
+	return &Text{Type: TypePlainText, Text: text}
+}
+
+// Markdown creates a markdown text object.
+func Markdown(text string) *Text {
+	return &Text{Type: TypeMarkdown, Text: text}
+}
+
+// ElementType returns the type of the text, plain_text or mrkdwn.
+func (t Text) ElementType() string {
+	return t.Type
+}
+
+// Validate checks the type of the text.
+func (t Text) Validate() error {
+	if t.Type != TypePlainText && t.Type != TypeMarkdown {
+		return fmt.Errorf("invalid text type %q", t.Type)
+	}
+	return nil
+}
+
+// validateText checks that the text is set, valid and at most limit characters long.
+// When plain is true, only plain text is allowed.
+func validateText(name string, text *Text, limit int, plain bool) error {
+	if text == nil {
+		return fmt.Errorf("missing %s", name)
+	}
+	if err := text.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if plain && text.Type != TypePlainText {
+		return fmt.Errorf("%s: must be %s", name, TypePlainText)
+	}
+	return validateLength(name, text.Text, limit)
+}
+
+// validateOptionalText checks the text when it is set.
+func validateOptionalText(name string, text *Text, limit int, plain bool) error {
+	if text == nil {
+		return nil
+	}
+	return validateText(name, text, limit, plain)
+}
+
+func validateLength(name, value string, limit int) error {
+	if utf8.RuneCountInString(value) > limit {
+		return fmt.Errorf("%s exceeds %d characters", name, limit)
+	}
+	return nil
+}
+
+// Option is an option of a select menu, an overflow menu, checkboxes or radio buttons.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#option
+type Option struct {
+	Text        *Text  `json:"text"`
+	Description *Text  `json:"description,omitempty"`
+	Value       string `json:"value"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Validate checks the limits of the option.
+func (o Option) Validate() error {
+	if err := validateText("option text", o.Text, maxOptionTextLength, false); err != nil {
+		return err
+	}
+	if err := validateOptionalText(
+		"option description", o.Description, maxOptionTextLength, false,
+	); err != nil {
+		return err
+	}
+	return validateLength("option value", o.Value, maxOptionValueLength)
+}
+
+// OptionGroup groups the options of a select menu under a label.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#option_group
+type OptionGroup struct {
+	Label   *Text     `json:"label"`
+	Options []*Option `json:"options"`
+}
+
+// Validate checks the limits of the option group.
+func (g OptionGroup) Validate() error {
+	if err := validateText("option group label", g.Label, maxGroupLabelLength, true); err != nil {
+		return err
+	}
+	return validateOptions(g.Options, 1, maxSelectOptions)
+}
+
+// Confirm is a dialog asking users to confirm the action of an interactive element.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#confirm
+type Confirm struct {
+	Title   *Text  `json:"title"`
+	Text    *Text  `json:"text"`
+	Confirm *Text  `json:"confirm"`
+	Deny    *Text  `json:"deny"`
+	Style   string `json:"style,omitempty"`
+}
+
+// Validate checks the limits of the dialog.
+func (c Confirm) Validate() error {
+	if err := validateText("confirm title", c.Title, maxConfirmTitle, true); err != nil {
+		return err
+	}
+	if err := validateText("confirm text", c.Text, maxConfirmTextLength, false); err != nil {
+		return err
+	}
+	if err := validateText("confirm button", c.Confirm, maxConfirmButton, true); err != nil {
+		return err
+	}
+	if err := validateText("deny button", c.Deny, maxConfirmButton, true); err != nil {
+		return err
+	}
+	return validateStyle(c.Style)
+}
+
+// validateConfirm checks the dialog when it is set.
+func validateConfirm(confirm *Confirm) error {
+	if confirm == nil {
+		return nil
+	}
+	return confirm.Validate()
+}
+
+// ConversationFilter filters the conversations listed by a conversations select menu.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#filter_conversations
+type ConversationFilter struct {
+	Include                       []string `json:"include,omitempty"`
+	ExcludeExternalSharedChannels bool     `json:"exclude_external_shared_channels,omitempty"`
+	ExcludeBotUsers               bool     `json:"exclude_bot_users,omitempty"`
+}
+
+// DispatchActionConfig sets when a text input dispatches a block action.
+// Doc: https://api.slack.com/reference/block-kit/composition-objects#dispatch_action_config
+type DispatchActionConfig struct {
+	TriggerActionsOn []string `json:"trigger_actions_on,omitempty"`
+}
+
+func validateStyle(style string) error {
+	if style != "" && style != "primary" && style != "danger" {
+		return fmt.Errorf("invalid style %q", style)
+	}
+	return nil
+}
+
+func validateOptions(options []*Option, minOptions, maxOptions int) error {
+	if len(options) < minOptions || len(options) > maxOptions {
+		return fmt.Errorf("must have between %d and %d options", minOptions, maxOptions)
+	}
+	for _, option := range options {
+		if option == nil {
+			return fmt.Errorf("missing option")
+		}
+		if err := option.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Button is a button element.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#button
+type Button struct {
+	Text               *Text    `json:"text"`
+	Confirm            *Confirm `json:"confirm,omitempty"`
+	ActionID           string   `json:"action_id,omitempty"`
+	URL                string   `json:"url,omitempty"`
+	Value              string   `json:"value,omitempty"`
+	Style              string   `json:"style,omitempty"`
+	AccessibilityLabel string   `json:"accessibility_label,omitempty"`
+}
+
+// ElementType returns button.
+func (Button) ElementType() string { return "button" }
+
+// Validate checks the limits of the button.
+func (b Button) Validate() error {
+	if err := validateText("text", b.Text, maxButtonTextLength, true); err != nil {
+		return err
+	}
+	if err := validateStyle(b.Style); err != nil {
+		return err
+	}
+	if err := validateLength("url", b.URL, maxURLLength); err != nil {
+		return err
+	}
+	if err := validateLength("value", b.Value, maxValueLength); err != nil {
+		return err
+	}
+	return validateConfirm(b.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (b Button) MarshalJSON() ([]byte, error) {
+	type alias Button
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{b.ElementType(), alias(b)})
+}
+
+// Overflow is an overflow menu.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#overflow
+type Overflow struct {
+	Confirm  *Confirm  `json:"confirm,omitempty"`
+	ActionID string    `json:"action_id,omitempty"`
+	Options  []*Option `json:"options"`
+}
+
+// ElementType returns overflow.
+func (Overflow) ElementType() string { return "overflow" }
+
+// Validate checks the limits of the overflow menu.
+func (o Overflow) Validate() error {
+	if err := validateOptions(o.Options, minOverflowOptions, maxOverflowOptions); err != nil {
+		return err
+	}
+	return validateConfirm(o.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (o Overflow) MarshalJSON() ([]byte, error) {
+	type alias Overflow
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{o.ElementType(), alias(o)})
+}
+
+// DatePicker is a date picker, InitialDate is formatted as YYYY-MM-DD.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#datepicker
+type DatePicker struct {
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	ActionID    string   `json:"action_id,omitempty"`
+	InitialDate string   `json:"initial_date,omitempty"`
+	FocusOnLoad bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns datepicker.
+func (DatePicker) ElementType() string { return "datepicker" }
+
+// Validate checks the limits of the date picker.
+func (d DatePicker) Validate() error {
+	if err := validateOptionalText(
+		"placeholder", d.Placeholder, maxPlaceholderLength, true,
+	); err != nil {
+		return err
+	}
+	return validateConfirm(d.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (d DatePicker) MarshalJSON() ([]byte, error) {
+	type alias DatePicker
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{d.ElementType(), alias(d)})
+}
+
+// Checkboxes is a group of checkboxes.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#checkboxes
+type Checkboxes struct {
+	Confirm        *Confirm  `json:"confirm,omitempty"`
+	ActionID       string    `json:"action_id,omitempty"`
+	Options        []*Option `json:"options"`
+	InitialOptions []*Option `json:"initial_options,omitempty"`
+	FocusOnLoad    bool      `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns checkboxes.
+func (Checkboxes) ElementType() string { return "checkboxes" }
+
+// Validate checks the limits of the checkboxes.
+func (c Checkboxes) Validate() error {
+	if err := validateOptions(c.Options, 1, maxCheckboxOptions); err != nil {
+		return err
+	}
+	return validateConfirm(c.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (c Checkboxes) MarshalJSON() ([]byte, error) {
+	type alias Checkboxes
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{c.ElementType(), alias(c)})
+}
+
+// RadioButtons is a group of radio buttons.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#radio
+type RadioButtons struct {
+	InitialOption *Option   `json:"initial_option,omitempty"`
+	Confirm       *Confirm  `json:"confirm,omitempty"`
+	ActionID      string    `json:"action_id,omitempty"`
+	Options       []*Option `json:"options"`
+	FocusOnLoad   bool      `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns radio_buttons.
+func (RadioButtons) ElementType() string { return "radio_buttons" }
+
+// Validate checks the limits of the radio buttons.
+func (r RadioButtons) Validate() error {
+	if err := validateOptions(r.Options, 1, maxCheckboxOptions); err != nil {
+		return err
+	}
+	return validateConfirm(r.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (r RadioButtons) MarshalJSON() ([]byte, error) {
+	type alias RadioButtons
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{r.ElementType(), alias(r)})
+}
+
+// PlainTextInput is a text input, used in input blocks.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#input
+type PlainTextInput struct {
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	MinLength            int                   `json:"min_length,omitempty"`
+	MaxLength            int                   `json:"max_length,omitempty"`
+	Multiline            bool                  `json:"multiline,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns plain_text_input.
+func (PlainTextInput) ElementType() string { return "plain_text_input" }
+
+// Validate checks the limits of the text input.
+func (p PlainTextInput) Validate() error {
+	if err := validateOptionalText(
+		"placeholder", p.Placeholder, maxPlaceholderLength, true,
+	); err != nil {
+		return err
+	}
+	if p.MinLength > maxInputLength {
+		return fmt.Errorf("min length exceeds %d", maxInputLength)
+	}
+	return nil
+}
+
+// MarshalJSON adds the type of the element.
+func (p PlainTextInput) MarshalJSON() ([]byte, error) {
+	type alias PlainTextInput
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{p.ElementType(), alias(p)})
+}
+
+// TimePicker is a time picker, InitialTime is formatted as HH:mm.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#timepicker
+type TimePicker struct {
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	ActionID    string   `json:"action_id,omitempty"`
+	InitialTime string   `json:"initial_time,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"`
+	FocusOnLoad bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns timepicker.
+func (TimePicker) ElementType() string { return "timepicker" }
+
+// Validate checks the limits of the time picker.
+func (p TimePicker) Validate() error {
+	if err := validateOptionalText(
+		"placeholder", p.Placeholder, maxPlaceholderLength, true,
+	); err != nil {
+		return err
+	}
+	return validateConfirm(p.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (p TimePicker) MarshalJSON() ([]byte, error) {
+	type alias TimePicker
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{p.ElementType(), alias(p)})
+}
+
+// DateTimePicker is a date and time picker, InitialDateTime is a UNIX timestamp in seconds.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#datetimepicker
+type DateTimePicker struct {
+	Confirm         *Confirm `json:"confirm,omitempty"`
+	ActionID        string   `json:"action_id,omitempty"`
+	InitialDateTime int64    `json:"initial_date_time,omitempty"`
+	FocusOnLoad     bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns datetimepicker.
+func (DateTimePicker) ElementType() string { return "datetimepicker" }
+
+// Validate checks the confirmation dialog of the picker.
+func (p DateTimePicker) Validate() error {
+	return validateConfirm(p.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (p DateTimePicker) MarshalJSON() ([]byte, error) {
+	type alias DateTimePicker
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{p.ElementType(), alias(p)})
+}
+
+// EmailInput is an email address input, used in input blocks.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#email
+type EmailInput struct {
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns email_text_input.
+func (EmailInput) ElementType() string { return "email_text_input" }
+
+// Validate checks the limits of the email input.
+func (e EmailInput) Validate() error {
+	return validateOptionalText("placeholder", e.Placeholder, maxPlaceholderLength, true)
+}
+
+// MarshalJSON adds the type of the element.
+func (e EmailInput) MarshalJSON() ([]byte, error) {
+	type alias EmailInput
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{e.ElementType(), alias(e)})
+}
+
+// URLInput is a URL input, used in input blocks.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#url
+type URLInput struct {
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns url_text_input.
+func (URLInput) ElementType() string { return "url_text_input" }
+
+// Validate checks the limits of the URL input.
+func (u URLInput) Validate() error {
+	return validateOptionalText("placeholder", u.Placeholder, maxPlaceholderLength, true)
+}
+
+// MarshalJSON adds the type of the element.
+func (u URLInput) MarshalJSON() ([]byte, error) {
+	type alias URLInput
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{u.ElementType(), alias(u)})
+}
+
+// NumberInput is a number input, used in input blocks.
+// InitialValue, MinValue and MaxValue are numbers formatted as strings.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#number
+type NumberInput struct {
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	MinValue             string                `json:"min_value,omitempty"`
+	MaxValue             string                `json:"max_value,omitempty"`
+	IsDecimalAllowed     bool                  `json:"is_decimal_allowed"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns number_input.
+func (NumberInput) ElementType() string { return "number_input" }
+
+// Validate checks the limits of the number input.
+func (n NumberInput) Validate() error {
+	return validateOptionalText("placeholder", n.Placeholder, maxPlaceholderLength, true)
+}
+
+// MarshalJSON adds the type of the element.
+func (n NumberInput) MarshalJSON() ([]byte, error) {
+	type alias NumberInput
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{n.ElementType(), alias(n)})
+}
+
+// ImageElement is an image inside a section accessory or a context block.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#image
+type ImageElement struct {
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// ElementType returns image.
+func (ImageElement) ElementType() string { return "image" }
+
+// Validate checks that the image has a URL and an alternative text.
+func (i ImageElement) Validate() error {
+	if i.ImageURL == "" {
+		return fmt.Errorf("missing image url")
+	}
+	if i.AltText == "" {
+		return fmt.Errorf("missing alt text")
+	}
+	return nil
+}
+
+// MarshalJSON adds the type of the element.
+func (i ImageElement) MarshalJSON() ([]byte, error) {
+	type alias ImageElement
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{i.ElementType(), alias(i)})
+}
@@ -0,0 +1,107 @@
+package blockkit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestElementsValidate(t *testing.T) {
+	t.Run("should limit the text of buttons", func(t *testing.T) {
+		button := Button{Text: PlainText(strings.Repeat("a", 76))}
+
+		err := button.Validate()
+
+		assert.AreEqual(t, err.Error(), "text exceeds 75 characters")
+	})
+
+	t.Run("should limit the number of options", func(t *testing.T) {
+		options := make([]*Option, 6)
+		for i := range options {
+			options[i] = &Option{Text: PlainText("Silence"), Value: "silence"}
+		}
+
+		overflowErr := Overflow{Options: options}.Validate()
+		selectErr := StaticSelect{}.Validate()
+
+		assert.AreEqual(t, overflowErr.Error(), "must have between 1 and 5 options")
+		assert.AreEqual(t, selectErr.Error(), "must have between 1 and 100 options")
+	})
+
+	t.Run("should validate options", func(t *testing.T) {
+		checkboxes := Checkboxes{Options: []*Option{
+			{Text: PlainText("Page"), Value: strings.Repeat("a", 151)},
+		}}
+
+		err := checkboxes.Validate()
+
+		assert.AreEqual(t, err.Error(), "option value exceeds 150 characters")
+	})
+
+	t.Run("should require plain text placeholders", func(t *testing.T) {
+		input := PlainTextInput{Placeholder: Markdown("*reason*")}
+
+		err := input.Validate()
+
+		assert.AreEqual(t, err.Error(), "placeholder: must be plain_text")
+	})
+
+	t.Run("should validate confirmation dialogs", func(t *testing.T) {
+		button := Button{Text: PlainText("Rollback"), Confirm: &Confirm{
+			Title:   PlainText("Are you sure?"),
+			Text:    Markdown("This rolls back *api*."),
+			Confirm: PlainText("Do it"),
+		}}
+
+		err := button.Validate()
+
+		assert.AreEqual(t, err.Error(), "missing deny button")
+	})
+
+	t.Run("should require options or option groups in static selects", func(t *testing.T) {
+		option := &Option{Text: PlainText("SEV1"), Value: "sev1"}
+		group := &OptionGroup{Label: PlainText("Paging"), Options: []*Option{option}}
+
+		bothErr := StaticSelect{
+			Options:      []*Option{option},
+			OptionGroups: []*OptionGroup{group},
+		}.Validate()
+		groupsErr := MultiStaticSelect{OptionGroups: []*OptionGroup{group}}.Validate()
+
+		assert.AreEqual(t, bothErr.Error(), "must have options or option groups, not both")
+		assert.IsNil(t, groupsErr)
+	})
+
+	t.Run("should accept valid elements", func(t *testing.T) {
+		option := &Option{Text: PlainText("1 hour"), Value: "1h"}
+		elements := []Element{
+			PlainText("Retry"),
+			&Button{Text: PlainText("Retry"), Style: "primary", Value: "retry"},
+			&StaticSelect{Options: []*Option{option}, InitialOption: option},
+			&Overflow{Options: []*Option{option}},
+			&DatePicker{InitialDate: "2024-06-01"},
+			&Checkboxes{Options: []*Option{option}},
+			&RadioButtons{Options: []*Option{option}},
+			&PlainTextInput{Multiline: true},
+			&ImageElement{ImageURL: "https://example.com/logo.png", AltText: "logo"},
+			&ExternalSelect{MinQueryLength: 3},
+			&MultiExternalSelect{MaxSelectedItems: 2},
+			&UsersSelect{InitialUser: "U123"},
+			&MultiUsersSelect{InitialUsers: []string{"U123"}},
+			&ConversationsSelect{Filter: &ConversationFilter{Include: []string{"im"}}},
+			&MultiConversationsSelect{DefaultToCurrentConversation: true},
+			&ChannelsSelect{InitialChannel: "C123"},
+			&MultiChannelsSelect{InitialChannels: []string{"C123"}},
+			&TimePicker{InitialTime: "13:37"},
+			&DateTimePicker{InitialDateTime: 1717243200},
+			&EmailInput{},
+			&URLInput{},
+			&NumberInput{MinValue: "1", MaxValue: "10"},
+		}
+
+		for _, element := range elements {
+			assert.IsNil(t, element.Validate(), element.ElementType())
+		}
+	})
+}
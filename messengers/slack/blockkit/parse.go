@@ -0,0 +1,314 @@
+package blockkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Parse decodes and validates blocks exported by the Block Kit Builder,
+// either a JSON array of blocks or an object with a blocks array.
+// Fields that are not modelled by this package are reported as errors
+// instead of being dropped, so parsed blocks always marshal back to the same message.
+func Parse(data []byte) (Blocks, error) {
+	data = bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(data, []byte("{")) {
+		var payload struct {
+			Blocks json.RawMessage `json:"blocks"`
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("error unmarshalling blocks: %w", err)
+		}
+		data = payload.Blocks
+		if len(data) == 0 {
+			data = []byte("null")
+		}
+	}
+
+	var blocks Blocks
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, fmt.Errorf("error unmarshalling blocks: %w", err)
+	}
+
+	if err := blocks.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkUnsupported(data, blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// checkUnsupported compares the decoded JSON with the blocks marshalled back
+// and reports the first field that was lost.
+func checkUnsupported(data []byte, blocks Blocks) error {
+	var decoded []any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("error unmarshalling blocks: %w", err)
+	}
+
+	encoded, err := json.Marshal(blocks)
+	if err != nil {
+		return fmt.Errorf("error marshalling blocks: %w", err)
+	}
+	var marshalled []any
+	if err := json.Unmarshal(encoded, &marshalled); err != nil {
+		return fmt.Errorf("error unmarshalling blocks: %w", err)
+	}
+
+	for i, block := range blocks {
+		if field := lostField("", decoded[i], marshalled[i]); field != "" {
+			return fmt.Errorf("block %d: %s: unsupported field %q", i, block.BlockType(), field)
+		}
+	}
+	return nil
+}
+
+// lostField returns the path of the first non-empty field of in that is missing in out.
+// Booleans are compared, since a missing boolean may not mean false to Slack.
+func lostField(path string, in, out any) string {
+	switch in := in.(type) {
+	case bool:
+		if value, ok := out.(bool); !ok || value != in {
+			return path
+		}
+	case map[string]any:
+		outMap, _ := out.(map[string]any)
+		keys := make([]string, 0, len(in))
+		for key := range in {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if isEmpty(in[key]) {
+				continue
+			}
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			value, ok := outMap[key]
+			if !ok {
+				return fieldPath
+			}
+			if field := lostField(fieldPath, in[key], value); field != "" {
+				return field
+			}
+		}
+	case []any:
+		outSlice, _ := out.([]any)
+		for i, item := range in {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if i >= len(outSlice) {
+				return itemPath
+			}
+			if field := lostField(itemPath, item, outSlice[i]); field != "" {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// isEmpty reports whether the JSON value is omitted when marshalling with omitempty.
+// Booleans are never empty, so an explicit false is checked as well.
+func isEmpty(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case float64:
+		return value == 0
+	case []any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
+
+// FromMaps decodes and validates the content of a slack.Message.
+func FromMaps(maps []map[string]any) (Blocks, error) {
+	data, err := json.Marshal(maps)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling blocks: %w", err)
+	}
+	return Parse(data)
+}
+
+// UnmarshalJSON decodes each block according to its type.
+func (b *Blocks) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	blocks := make(Blocks, 0, len(raws))
+	for _, raw := range raws {
+		block, err := decodeBlock(raw)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, block)
+	}
+	*b = blocks
+	return nil
+}
+
+func decodeBlock(raw json.RawMessage) (Block, error) {
+	typ, err := decodeType(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var block Block
+	switch typ {
+	case "section":
+		block = &Section{}
+	case "header":
+		block = &Header{}
+	case "divider":
+		block = &Divider{}
+	case "context":
+		block = &Context{}
+	case "actions":
+		block = &Actions{}
+	case "image":
+		block = &Image{}
+	case "rich_text":
+		block = &RichText{}
+	case "input":
+		block = &Input{}
+	default:
+		return nil, fmt.Errorf("unknown block type %q", typ)
+	}
+
+	if err := json.Unmarshal(raw, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func decodeElement(raw json.RawMessage) (Element, error) {
+	typ, err := decodeType(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var element Element
+	switch typ {
+	case TypePlainText, TypeMarkdown:
+		element = &Text{}
+	case "button":
+		element = &Button{}
+	case "static_select":
+		element = &StaticSelect{}
+	case "multi_static_select":
+		element = &MultiStaticSelect{}
+	case "external_select":
+		element = &ExternalSelect{}
+	case "multi_external_select":
+		element = &MultiExternalSelect{}
+	case "users_select":
+		element = &UsersSelect{}
+	case "multi_users_select":
+		element = &MultiUsersSelect{}
+	case "conversations_select":
+		element = &ConversationsSelect{}
+	case "multi_conversations_select":
+		element = &MultiConversationsSelect{}
+	case "channels_select":
+		element = &ChannelsSelect{}
+	case "multi_channels_select":
+		element = &MultiChannelsSelect{}
+	case "overflow":
+		element = &Overflow{}
+	case "datepicker":
+		element = &DatePicker{}
+	case "timepicker":
+		element = &TimePicker{}
+	case "datetimepicker":
+		element = &DateTimePicker{}
+	case "checkboxes":
+		element = &Checkboxes{}
+	case "radio_buttons":
+		element = &RadioButtons{}
+	case "plain_text_input":
+		element = &PlainTextInput{}
+	case "email_text_input":
+		element = &EmailInput{}
+	case "url_text_input":
+		element = &URLInput{}
+	case "number_input":
+		element = &NumberInput{}
+	case "image":
+		element = &ImageElement{}
+	default:
+		return nil, fmt.Errorf("unknown element type %q", typ)
+	}
+
+	if err := json.Unmarshal(raw, element); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+func decodeElements(raws []json.RawMessage) ([]Element, error) {
+	if raws == nil {
+		return nil, nil
+	}
+
+	elements := make([]Element, 0, len(raws))
+	for _, raw := range raws {
+		element, err := decodeElement(raw)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func decodeRichTextElement(raw json.RawMessage) (RichTextElement, error) {
+	typ, err := decodeType(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var element RichTextElement
+	switch typ {
+	case "rich_text_section":
+		element = &RichTextSection{}
+	case "rich_text_list":
+		element = &RichTextList{}
+	case "rich_text_preformatted":
+		element = &RichTextPreformatted{}
+	case "rich_text_quote":
+		element = &RichTextQuote{}
+	default:
+		return nil, fmt.Errorf("unknown rich text element type %q", typ)
+	}
+
+	if err := json.Unmarshal(raw, element); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+func decodeType(raw json.RawMessage) (string, error) {
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return "", err
+	}
+	if typed.Type == "" {
+		return "", fmt.Errorf("missing type")
+	}
+	return typed.Type, nil
+}
@@ -0,0 +1,339 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+// builderPayload is a message exported by the Block Kit Builder.
+const builderPayload = `{
+	"blocks": [
+		{"type": "header", "text": {"type": "plain_text", "text": "Deploy failed", "emoji": true}},
+		{
+			"type": "section",
+			"block_id": "summary",
+			"text": {"type": "mrkdwn", "text": "*api* v1.2.3 failed on *prod*"},
+			"accessory": {
+				"type": "overflow",
+				"action_id": "more",
+				"options": [{"text": {"type": "plain_text", "text": "Silence"}, "value": "silence"}]
+			}
+		},
+		{
+			"type": "section",
+			"fields": [
+				{"type": "mrkdwn", "text": "*Env*\nprod"},
+				{"type": "mrkdwn", "text": "*Version*\nv1.2.3"}
+			]
+		},
+		{"type": "divider"},
+		{
+			"type": "image",
+			"title": {"type": "plain_text", "text": "Error rate"},
+			"image_url": "https://example.com/graph.png",
+			"alt_text": "error rate"
+		},
+		{
+			"type": "context",
+			"elements": [
+				{"type": "image", "image_url": "https://example.com/avatar.png", "alt_text": "ci"},
+				{"type": "mrkdwn", "text": "Triggered by <@U123>"}
+			]
+		},
+		{
+			"type": "rich_text",
+			"elements": [
+				{
+					"type": "rich_text_section",
+					"elements": [
+						{"type": "text", "text": "Rollback ", "style": {"bold": true}},
+						{"type": "link", "url": "https://example.com/runbook", "text": "runbook"}
+					]
+				},
+				{
+					"type": "rich_text_list",
+					"style": "ordered",
+					"elements": [
+						{"type": "rich_text_section", "elements": [{"type": "text", "text": "revert"}]}
+					]
+				},
+				{"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "make rollback"}]},
+				{"type": "rich_text_quote", "elements": [{"type": "user", "user_id": "U123"}]}
+			]
+		},
+		{
+			"type": "actions",
+			"elements": [
+				{
+					"type": "button",
+					"text": {"type": "plain_text", "text": "Retry"},
+					"style": "primary",
+					"value": "retry",
+					"action_id": "retry"
+				},
+				{
+					"type": "static_select",
+					"placeholder": {"type": "plain_text", "text": "Assign"},
+					"options": [{"text": {"type": "plain_text", "text": "Alice"}, "value": "alice"}]
+				},
+				{"type": "datepicker", "initial_date": "2024-06-01"},
+				{
+					"type": "checkboxes",
+					"options": [{"text": {"type": "mrkdwn", "text": "*Page*"}, "value": "page"}]
+				},
+				{
+					"type": "radio_buttons",
+					"options": [{"text": {"type": "plain_text", "text": "High"}, "value": "high"}]
+				}
+			]
+		},
+		{
+			"type": "input",
+			"label": {"type": "plain_text", "text": "Reason"},
+			"element": {"type": "plain_text_input", "multiline": true, "action_id": "reason"},
+			"optional": true
+		}
+	]
+}`
+
+// builderInteractivePayload is a message with the interactive elements of the Block Kit Builder.
+const builderInteractivePayload = `{
+	"blocks": [
+		{
+			"type": "section",
+			"text": {"type": "mrkdwn", "text": "Pick an on-call user"},
+			"accessory": {
+				"type": "users_select",
+				"placeholder": {"type": "plain_text", "text": "Select a user", "emoji": true},
+				"action_id": "users_select-action"
+			}
+		},
+		{
+			"type": "section",
+			"text": {"type": "mrkdwn", "text": "Post the incident in"},
+			"accessory": {
+				"type": "conversations_select",
+				"placeholder": {"type": "plain_text", "text": "Select conversations", "emoji": true},
+				"filter": {"include": ["public", "private"], "exclude_bot_users": true},
+				"action_id": "conversations_select-action"
+			}
+		},
+		{
+			"type": "actions",
+			"elements": [
+				{
+					"type": "button",
+					"text": {"type": "plain_text", "text": "Rollback", "emoji": true},
+					"style": "danger",
+					"value": "rollback",
+					"action_id": "actionId-0",
+					"confirm": {
+						"title": {"type": "plain_text", "text": "Are you sure?"},
+						"text": {"type": "mrkdwn", "text": "This rolls back *api* in prod."},
+						"confirm": {"type": "plain_text", "text": "Do it"},
+						"deny": {"type": "plain_text", "text": "Stop, I've changed my mind!"},
+						"style": "danger"
+					}
+				},
+				{
+					"type": "channels_select",
+					"placeholder": {"type": "plain_text", "text": "Select a channel", "emoji": true},
+					"initial_channel": "C123",
+					"action_id": "actionId-1"
+				},
+				{
+					"type": "static_select",
+					"placeholder": {"type": "plain_text", "text": "Severity", "emoji": true},
+					"option_groups": [
+						{
+							"label": {"type": "plain_text", "text": "Paging"},
+							"options": [{"text": {"type": "plain_text", "text": "SEV1"}, "value": "sev1"}]
+						}
+					],
+					"action_id": "actionId-2"
+				},
+				{
+					"type": "timepicker",
+					"initial_time": "13:37",
+					"placeholder": {"type": "plain_text", "text": "Select time", "emoji": true},
+					"action_id": "actionId-3"
+				},
+				{"type": "datetimepicker", "initial_date_time": 1717243200, "action_id": "actionId-4"}
+			]
+		},
+		{
+			"type": "input",
+			"element": {
+				"type": "multi_users_select",
+				"placeholder": {"type": "plain_text", "text": "Select users", "emoji": true},
+				"initial_users": ["U123"],
+				"max_selected_items": 3,
+				"action_id": "multi_users_select-action"
+			},
+			"label": {"type": "plain_text", "text": "Responders", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {
+				"type": "multi_conversations_select",
+				"placeholder": {"type": "plain_text", "text": "Select conversations", "emoji": true},
+				"action_id": "multi_conversations_select-action"
+			},
+			"label": {"type": "plain_text", "text": "Notify", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {
+				"type": "multi_channels_select",
+				"placeholder": {"type": "plain_text", "text": "Select channels", "emoji": true},
+				"action_id": "multi_channels_select-action"
+			},
+			"label": {"type": "plain_text", "text": "Channels", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {
+				"type": "multi_static_select",
+				"placeholder": {"type": "plain_text", "text": "Select options", "emoji": true},
+				"options": [{"text": {"type": "plain_text", "text": "api", "emoji": true}, "value": "api"}],
+				"action_id": "multi_static_select-action"
+			},
+			"label": {"type": "plain_text", "text": "Services", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {"type": "number_input", "is_decimal_allowed": false, "action_id": "number_input-action"},
+			"label": {"type": "plain_text", "text": "Replicas", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {"type": "url_text_input", "action_id": "url_text_input-action"},
+			"label": {"type": "plain_text", "text": "Dashboard", "emoji": true}
+		},
+		{
+			"type": "input",
+			"element": {"type": "email_text_input", "action_id": "email_text_input-action"},
+			"label": {"type": "plain_text", "text": "Contact", "emoji": true}
+		},
+		{
+			"type": "rich_text",
+			"elements": [
+				{
+					"type": "rich_text_section",
+					"elements": [
+						{"type": "usergroup", "usergroup_id": "S123"},
+						{"type": "text", "text": " since "},
+						{"type": "date", "timestamp": 1717243200, "format": "{date_short}", "fallback": "Jun 1"}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("should round-trip a block kit builder payload", func(t *testing.T) {
+		var expected struct {
+			Blocks []map[string]any `json:"blocks"`
+		}
+		_ = json.Unmarshal([]byte(builderPayload), &expected)
+
+		blocks, err := Parse([]byte(builderPayload))
+		maps, _ := blocks.Maps()
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(blocks), 9)
+		assert.AreEqual(t, maps, expected.Blocks)
+	})
+
+	t.Run("should round-trip the interactive elements of the builder", func(t *testing.T) {
+		var expected struct {
+			Blocks []map[string]any `json:"blocks"`
+		}
+		_ = json.Unmarshal([]byte(builderInteractivePayload), &expected)
+
+		blocks, err := Parse([]byte(builderInteractivePayload))
+		maps, _ := blocks.Maps()
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(blocks), 11)
+		assert.AreEqual(t, maps, expected.Blocks)
+	})
+
+	t.Run("should report fields that are not supported", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "divider"}, {"type": "actions", "elements": [` +
+			`{"type": "button", "text": {"type": "plain_text", "text": "Go"}, "unknown": 1}]}]`))
+
+		assert.AreEqual(
+			t,
+			err.Error(),
+			`block 1: actions: unsupported field "elements[0].unknown"`,
+		)
+	})
+
+	t.Run("should keep an explicit false emoji", func(t *testing.T) {
+		blocks, err := Parse([]byte(
+			`[{"type":"header","text":{"type":"plain_text","text":"x :smile:","emoji":false}}]`,
+		))
+		encoded, _ := json.Marshal(blocks)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(
+			t,
+			string(encoded),
+			`[{"type":"header","text":{"emoji":false,"type":"plain_text","text":"x :smile:"}}]`,
+		)
+	})
+
+	t.Run("should report booleans that are lost", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "section", ` +
+			`"text": {"type": "mrkdwn", "text": "a", "verbatim": false}}]`))
+
+		assert.AreEqual(t, err.Error(), `block 0: section: unsupported field "text.verbatim"`)
+	})
+
+	t.Run("should parse a json array of blocks", func(t *testing.T) {
+		blocks, err := Parse([]byte(`[{"type": "divider", "block_id": "top"}]`))
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, blocks, Blocks{&Divider{BlockID: "top"}})
+	})
+
+	t.Run("should report unknown block types", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "sectoin"}]`))
+
+		assert.AreEqual(t, err.Error(), `error unmarshalling blocks: unknown block type "sectoin"`)
+	})
+
+	t.Run("should report unknown element types", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "context", "elements": [{"type": "mrkdwm", "text": "a"}]}]`))
+
+		assert.AreEqual(t, err.Error(), `error unmarshalling blocks: unknown element type "mrkdwm"`)
+	})
+
+	t.Run("should validate parsed blocks", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "section", "text": {"type": "mrkdwm", "text": "a"}}]`))
+
+		assert.AreEqual(t, err.Error(), `block 0: section: text: invalid text type "mrkdwm"`)
+	})
+
+	t.Run("should return an error when the json is invalid", func(t *testing.T) {
+		_, err := Parse([]byte(`[{"type": "divider"`))
+
+		assert.AreEqual(t, strings.HasPrefix(err.Error(), "error unmarshalling blocks:"), true)
+	})
+}
+
+func TestFromMaps(t *testing.T) {
+	t.Run("should decode message content", func(t *testing.T) {
+		blocks, err := FromMaps([]map[string]any{
+			{"type": "header", "text": map[string]any{"type": "plain_text", "text": "Deploy"}},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, blocks, Blocks{NewHeader("Deploy")})
+	})
+}
@@ -0,0 +1,264 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RichText displays formatted text, lists, code blocks and quotes.
+// Doc: https://api.slack.com/reference/block-kit/blocks#rich_text
+type RichText struct {
+	BlockID  string            `json:"block_id,omitempty"`
+	Elements []RichTextElement `json:"elements"`
+}
+
+// BlockType returns rich_text.
+func (RichText) BlockType() string { return "rich_text" }
+
+// Validate checks that the block has elements.
+func (r RichText) Validate() error {
+	if len(r.Elements) == 0 {
+		return errors.New("missing elements")
+	}
+	for _, element := range r.Elements {
+		if element == nil {
+			return errors.New("missing element")
+		}
+		if err := element.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", element.RichTextType(), err)
+		}
+	}
+	return validateBlockID(r.BlockID)
+}
+
+// MarshalJSON adds the type of the block.
+func (r RichText) MarshalJSON() ([]byte, error) {
+	type alias RichText
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{r.BlockType(), alias(r)})
+}
+
+// UnmarshalJSON decodes the elements according to their types.
+func (r *RichText) UnmarshalJSON(data []byte) error {
+	type alias RichText
+	raw := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Elements == nil {
+		return nil
+	}
+
+	r.Elements = make([]RichTextElement, 0, len(raw.Elements))
+	for _, data := range raw.Elements {
+		element, err := decodeRichTextElement(data)
+		if err != nil {
+			return err
+		}
+		r.Elements = append(r.Elements, element)
+	}
+	return nil
+}
+
+// RichTextElement is a section, list, preformatted text or quote of a rich text block.
+type RichTextElement interface {
+	RichTextType() string
+	Validate() error
+}
+
+// RichTextStyle formats a rich text item.
+type RichTextStyle struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+	Strike bool `json:"strike,omitempty"`
+	Code   bool `json:"code,omitempty"`
+}
+
+// RichTextItem is an inline item of a rich text element:
+// text, link, emoji, user, user group, channel, broadcast or date, depending on its type.
+// Timestamp is the UNIX timestamp of a date, displayed with Format.
+type RichTextItem struct {
+	Style       *RichTextStyle `json:"style,omitempty"`
+	Type        string         `json:"type"`
+	Text        string         `json:"text,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Unicode     string         `json:"unicode,omitempty"`
+	UserID      string         `json:"user_id,omitempty"`
+	UsergroupID string         `json:"usergroup_id,omitempty"`
+	ChannelID   string         `json:"channel_id,omitempty"`
+	Range       string         `json:"range,omitempty"`
+	Format      string         `json:"format,omitempty"`
+	Fallback    string         `json:"fallback,omitempty"`
+	Timestamp   int64          `json:"timestamp,omitempty"`
+}
+
+// Validate checks that the item has the fields its type requires.
+func (i RichTextItem) Validate() error {
+	switch i.Type {
+	case "text":
+		if i.Text == "" {
+			return errors.New("missing text")
+		}
+	case "link":
+		if i.URL == "" {
+			return errors.New("missing url")
+		}
+	case "emoji":
+		if i.Name == "" {
+			return errors.New("missing emoji name")
+		}
+	case "user":
+		if i.UserID == "" {
+			return errors.New("missing user id")
+		}
+	case "usergroup":
+		if i.UsergroupID == "" {
+			return errors.New("missing usergroup id")
+		}
+	case "channel":
+		if i.ChannelID == "" {
+			return errors.New("missing channel id")
+		}
+	case "date":
+		if i.Timestamp == 0 || i.Format == "" {
+			return errors.New("missing timestamp or format")
+		}
+	case "broadcast":
+		if i.Range != "here" && i.Range != "channel" && i.Range != "everyone" {
+			return fmt.Errorf("invalid broadcast range %q", i.Range)
+		}
+	default:
+		return fmt.Errorf("invalid rich text item type %q", i.Type)
+	}
+	return nil
+}
+
+func validateItems(items []*RichTextItem) error {
+	if len(items) == 0 {
+		return errors.New("missing elements")
+	}
+	for _, item := range items {
+		if item == nil {
+			return errors.New("missing element")
+		}
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RichTextSection is a paragraph of rich text items.
+type RichTextSection struct {
+	Elements []*RichTextItem `json:"elements"`
+}
+
+// RichTextType returns rich_text_section.
+func (RichTextSection) RichTextType() string { return "rich_text_section" }
+
+// Validate checks the items of the section.
+func (s RichTextSection) Validate() error {
+	return validateItems(s.Elements)
+}
+
+// MarshalJSON adds the type of the element.
+func (s RichTextSection) MarshalJSON() ([]byte, error) {
+	type alias RichTextSection
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.RichTextType(), alias(s)})
+}
+
+// RichTextList is a bullet or ordered list of sections.
+type RichTextList struct {
+	Style    string             `json:"style"`
+	Elements []*RichTextSection `json:"elements"`
+	Indent   int                `json:"indent,omitempty"`
+	Offset   int                `json:"offset,omitempty"`
+	Border   int                `json:"border,omitempty"`
+}
+
+// RichTextType returns rich_text_list.
+func (RichTextList) RichTextType() string { return "rich_text_list" }
+
+// Validate checks the style and the sections of the list.
+func (l RichTextList) Validate() error {
+	if l.Style != "bullet" && l.Style != "ordered" {
+		return fmt.Errorf("invalid style %q", l.Style)
+	}
+	if len(l.Elements) == 0 {
+		return errors.New("missing elements")
+	}
+	for _, section := range l.Elements {
+		if section == nil {
+			return errors.New("missing element")
+		}
+		if err := section.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON adds the type of the element.
+func (l RichTextList) MarshalJSON() ([]byte, error) {
+	type alias RichTextList
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{l.RichTextType(), alias(l)})
+}
+
+// RichTextPreformatted is a code block.
+type RichTextPreformatted struct {
+	Elements []*RichTextItem `json:"elements"`
+	Border   int             `json:"border,omitempty"`
+}
+
+// RichTextType returns rich_text_preformatted.
+func (RichTextPreformatted) RichTextType() string { return "rich_text_preformatted" }
+
+// Validate checks the items of the code block.
+func (p RichTextPreformatted) Validate() error {
+	return validateItems(p.Elements)
+}
+
+// MarshalJSON adds the type of the element.
+func (p RichTextPreformatted) MarshalJSON() ([]byte, error) {
+	type alias RichTextPreformatted
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{p.RichTextType(), alias(p)})
+}
+
+// RichTextQuote is a quote.
+type RichTextQuote struct {
+	Elements []*RichTextItem `json:"elements"`
+	Border   int             `json:"border,omitempty"`
+}
+
+// RichTextType returns rich_text_quote.
+func (RichTextQuote) RichTextType() string { return "rich_text_quote" }
+
+// Validate checks the items of the quote.
+func (q RichTextQuote) Validate() error {
+	return validateItems(q.Elements)
+}
+
+// MarshalJSON adds the type of the element.
+func (q RichTextQuote) MarshalJSON() ([]byte, error) {
+	type alias RichTextQuote
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{q.RichTextType(), alias(q)})
+}
@@ -0,0 +1,355 @@
+package blockkit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// validateSelect checks the placeholder and the confirmation dialog of a select menu.
+func validateSelect(placeholder *Text, confirm *Confirm) error {
+	if err := validateOptionalText(
+		"placeholder", placeholder, maxPlaceholderLength, true,
+	); err != nil {
+		return err
+	}
+	return validateConfirm(confirm)
+}
+
+// validateStaticOptions checks that a static select menu has either options or option groups.
+func validateStaticOptions(options []*Option, groups []*OptionGroup) error {
+	if len(groups) == 0 {
+		return validateOptions(options, 1, maxSelectOptions)
+	}
+	if len(options) > 0 {
+		return fmt.Errorf("must have options or option groups, not both")
+	}
+	if len(groups) > maxOptionGroups {
+		return fmt.Errorf("option groups exceed %d", maxOptionGroups)
+	}
+	for _, group := range groups {
+		if group == nil {
+			return fmt.Errorf("missing option group")
+		}
+		if err := group.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMaxSelectedItems(maxSelectedItems int) error {
+	if maxSelectedItems < 0 {
+		return fmt.Errorf("invalid max selected items %d", maxSelectedItems)
+	}
+	return nil
+}
+
+// StaticSelect is a select menu with static options or option groups.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#static_select
+type StaticSelect struct {
+	Placeholder   *Text          `json:"placeholder,omitempty"`
+	InitialOption *Option        `json:"initial_option,omitempty"`
+	Confirm       *Confirm       `json:"confirm,omitempty"`
+	ActionID      string         `json:"action_id,omitempty"`
+	Options       []*Option      `json:"options,omitempty"`
+	OptionGroups  []*OptionGroup `json:"option_groups,omitempty"`
+	FocusOnLoad   bool           `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns static_select.
+func (StaticSelect) ElementType() string { return "static_select" }
+
+// Validate checks the limits of the select menu.
+func (s StaticSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	return validateStaticOptions(s.Options, s.OptionGroups)
+}
+
+// MarshalJSON adds the type of the element.
+func (s StaticSelect) MarshalJSON() ([]byte, error) {
+	type alias StaticSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// MultiStaticSelect is a multi-select menu with static options or option groups.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#static_multi_select
+type MultiStaticSelect struct {
+	Placeholder      *Text          `json:"placeholder,omitempty"`
+	Confirm          *Confirm       `json:"confirm,omitempty"`
+	ActionID         string         `json:"action_id,omitempty"`
+	Options          []*Option      `json:"options,omitempty"`
+	OptionGroups     []*OptionGroup `json:"option_groups,omitempty"`
+	InitialOptions   []*Option      `json:"initial_options,omitempty"`
+	MaxSelectedItems int            `json:"max_selected_items,omitempty"`
+	FocusOnLoad      bool           `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns multi_static_select.
+func (MultiStaticSelect) ElementType() string { return "multi_static_select" }
+
+// Validate checks the limits of the multi-select menu.
+func (s MultiStaticSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	if err := validateMaxSelectedItems(s.MaxSelectedItems); err != nil {
+		return err
+	}
+	return validateStaticOptions(s.Options, s.OptionGroups)
+}
+
+// MarshalJSON adds the type of the element.
+func (s MultiStaticSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiStaticSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// ExternalSelect is a select menu whose options are loaded from the app.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#external_select
+type ExternalSelect struct {
+	Placeholder    *Text    `json:"placeholder,omitempty"`
+	InitialOption  *Option  `json:"initial_option,omitempty"`
+	Confirm        *Confirm `json:"confirm,omitempty"`
+	ActionID       string   `json:"action_id,omitempty"`
+	MinQueryLength int      `json:"min_query_length,omitempty"`
+	FocusOnLoad    bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns external_select.
+func (ExternalSelect) ElementType() string { return "external_select" }
+
+// Validate checks the limits of the select menu.
+func (s ExternalSelect) Validate() error {
+	return validateSelect(s.Placeholder, s.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (s ExternalSelect) MarshalJSON() ([]byte, error) {
+	type alias ExternalSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// MultiExternalSelect is a multi-select menu whose options are loaded from the app.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#external_multi_select
+type MultiExternalSelect struct {
+	Placeholder      *Text     `json:"placeholder,omitempty"`
+	Confirm          *Confirm  `json:"confirm,omitempty"`
+	ActionID         string    `json:"action_id,omitempty"`
+	InitialOptions   []*Option `json:"initial_options,omitempty"`
+	MinQueryLength   int       `json:"min_query_length,omitempty"`
+	MaxSelectedItems int       `json:"max_selected_items,omitempty"`
+	FocusOnLoad      bool      `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns multi_external_select.
+func (MultiExternalSelect) ElementType() string { return "multi_external_select" }
+
+// Validate checks the limits of the multi-select menu.
+func (s MultiExternalSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	return validateMaxSelectedItems(s.MaxSelectedItems)
+}
+
+// MarshalJSON adds the type of the element.
+func (s MultiExternalSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiExternalSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// UsersSelect is a select menu listing the users of the workspace.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#users_select
+type UsersSelect struct {
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	ActionID    string   `json:"action_id,omitempty"`
+	InitialUser string   `json:"initial_user,omitempty"`
+	FocusOnLoad bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns users_select.
+func (UsersSelect) ElementType() string { return "users_select" }
+
+// Validate checks the limits of the select menu.
+func (s UsersSelect) Validate() error {
+	return validateSelect(s.Placeholder, s.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (s UsersSelect) MarshalJSON() ([]byte, error) {
+	type alias UsersSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// MultiUsersSelect is a multi-select menu listing the users of the workspace.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#users_multi_select
+type MultiUsersSelect struct {
+	Placeholder      *Text    `json:"placeholder,omitempty"`
+	Confirm          *Confirm `json:"confirm,omitempty"`
+	ActionID         string   `json:"action_id,omitempty"`
+	InitialUsers     []string `json:"initial_users,omitempty"`
+	MaxSelectedItems int      `json:"max_selected_items,omitempty"`
+	FocusOnLoad      bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns multi_users_select.
+func (MultiUsersSelect) ElementType() string { return "multi_users_select" }
+
+// Validate checks the limits of the multi-select menu.
+func (s MultiUsersSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	return validateMaxSelectedItems(s.MaxSelectedItems)
+}
+
+// MarshalJSON adds the type of the element.
+func (s MultiUsersSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiUsersSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// ConversationsSelect is a select menu listing public and private channels,
+// direct messages and group direct messages.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#conversations_select
+type ConversationsSelect struct {
+	Placeholder                  *Text               `json:"placeholder,omitempty"`
+	Confirm                      *Confirm            `json:"confirm,omitempty"`
+	Filter                       *ConversationFilter `json:"filter,omitempty"`
+	ActionID                     string              `json:"action_id,omitempty"`
+	InitialConversation          string              `json:"initial_conversation,omitempty"`
+	DefaultToCurrentConversation bool                `json:"default_to_current_conversation,omitempty"`
+	ResponseURLEnabled           bool                `json:"response_url_enabled,omitempty"`
+	FocusOnLoad                  bool                `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns conversations_select.
+func (ConversationsSelect) ElementType() string { return "conversations_select" }
+
+// Validate checks the limits of the select menu.
+func (s ConversationsSelect) Validate() error {
+	return validateSelect(s.Placeholder, s.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (s ConversationsSelect) MarshalJSON() ([]byte, error) {
+	type alias ConversationsSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// MultiConversationsSelect is a multi-select menu listing public and private channels,
+// direct messages and group direct messages.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#conversation_multi_select
+type MultiConversationsSelect struct {
+	Placeholder                  *Text               `json:"placeholder,omitempty"`
+	Confirm                      *Confirm            `json:"confirm,omitempty"`
+	Filter                       *ConversationFilter `json:"filter,omitempty"`
+	ActionID                     string              `json:"action_id,omitempty"`
+	InitialConversations         []string            `json:"initial_conversations,omitempty"`
+	MaxSelectedItems             int                 `json:"max_selected_items,omitempty"`
+	DefaultToCurrentConversation bool                `json:"default_to_current_conversation,omitempty"`
+	FocusOnLoad                  bool                `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns multi_conversations_select.
+func (MultiConversationsSelect) ElementType() string { return "multi_conversations_select" }
+
+// Validate checks the limits of the multi-select menu.
+func (s MultiConversationsSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	return validateMaxSelectedItems(s.MaxSelectedItems)
+}
+
+// MarshalJSON adds the type of the element.
+func (s MultiConversationsSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiConversationsSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// ChannelsSelect is a select menu listing the public channels.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#channels_select
+type ChannelsSelect struct {
+	Placeholder        *Text    `json:"placeholder,omitempty"`
+	Confirm            *Confirm `json:"confirm,omitempty"`
+	ActionID           string   `json:"action_id,omitempty"`
+	InitialChannel     string   `json:"initial_channel,omitempty"`
+	ResponseURLEnabled bool     `json:"response_url_enabled,omitempty"`
+	FocusOnLoad        bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns channels_select.
+func (ChannelsSelect) ElementType() string { return "channels_select" }
+
+// Validate checks the limits of the select menu.
+func (s ChannelsSelect) Validate() error {
+	return validateSelect(s.Placeholder, s.Confirm)
+}
+
+// MarshalJSON adds the type of the element.
+func (s ChannelsSelect) MarshalJSON() ([]byte, error) {
+	type alias ChannelsSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
+
+// MultiChannelsSelect is a multi-select menu listing the public channels.
+// Doc: https://api.slack.com/reference/block-kit/block-elements#channel_multi_select
+type MultiChannelsSelect struct {
+	Placeholder      *Text    `json:"placeholder,omitempty"`
+	Confirm          *Confirm `json:"confirm,omitempty"`
+	ActionID         string   `json:"action_id,omitempty"`
+	InitialChannels  []string `json:"initial_channels,omitempty"`
+	MaxSelectedItems int      `json:"max_selected_items,omitempty"`
+	FocusOnLoad      bool     `json:"focus_on_load,omitempty"`
+}
+
+// ElementType returns multi_channels_select.
+func (MultiChannelsSelect) ElementType() string { return "multi_channels_select" }
+
+// Validate checks the limits of the multi-select menu.
+func (s MultiChannelsSelect) Validate() error {
+	if err := validateSelect(s.Placeholder, s.Confirm); err != nil {
+		return err
+	}
+	return validateMaxSelectedItems(s.MaxSelectedItems)
+}
+
+// MarshalJSON adds the type of the element.
+func (s MultiChannelsSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiChannelsSelect
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{s.ElementType(), alias(s)})
+}
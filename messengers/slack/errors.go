@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrRateLimited matches errors returned when Slack rate limits the request.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrMessageNotFound matches errors returned when the message to update or delete
+	// does not exist, including scheduled messages.
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrCantUpdateMessage matches errors returned when the message cannot be updated,
+	// e.g. because it was not posted by the app.
+	ErrCantUpdateMessage = errors.New("cannot update message")
+	// ErrCantDeleteMessage matches errors returned when the message cannot be deleted.
+	ErrCantDeleteMessage = errors.New("cannot delete message")
+	// ErrUserNotFound matches errors returned when no user has the email looked up.
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// errorCodes maps Slack error codes to the errors they match.
+var errorCodes = map[string]error{
+	"ratelimited":                  ErrRateLimited,
+	"message_not_found":            ErrMessageNotFound,
+	"cant_update_message":          ErrCantUpdateMessage,
+	"cant_delete_message":          ErrCantDeleteMessage,
+	"users_not_found":              ErrUserNotFound,
+	"invalid_scheduled_message_id": ErrMessageNotFound,
+}
+
+// Error is returned when Slack rejects a message.
+// StatusCode is the HTTP status code of the response.
+// Code is the error code returned by the Slack API (e.g. channel_not_found).
+// Doc: https://api.slack.com/methods/chat.postMessage#errors
+type Error struct {
+	Code       string
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("error sending message: %s", e.Code)
+	}
+	return fmt.Sprintf("error sending message: status-code: %d", e.StatusCode)
+}
+
+// HTTPStatusCode returns the HTTP status code of the response.
+func (e *Error) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// ProviderCode returns the error code returned by the Slack API.
+func (e *Error) ProviderCode() string {
+	return e.Code
+}
+
+// Is reports whether the error matches target,
+// so errors.Is(err, ErrRateLimited) detects rate limits
+// and errors.Is(err, ErrMessageNotFound) detects missing messages.
+func (e *Error) Is(target error) bool {
+	if target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return e.Code != "" && errorCodes[e.Code] == target
+}
@@ -0,0 +1,61 @@
+package slack
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("should format error with the Slack error code", func(t *testing.T) {
+		err := &Error{StatusCode: http.StatusOK, Code: "channel_not_found"}
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: channel_not_found"))
+	})
+
+	t.Run("should format error with the status code", func(t *testing.T) {
+		err := &Error{StatusCode: http.StatusBadGateway}
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: status-code: 502"))
+	})
+
+	t.Run("should match ErrRateLimited on status code 429", func(t *testing.T) {
+		err := error(&Error{StatusCode: http.StatusTooManyRequests})
+
+		assert.AreEqual(t, errors.Is(err, ErrRateLimited), true)
+	})
+
+	t.Run("should match ErrRateLimited on ratelimited code", func(t *testing.T) {
+		err := error(&Error{StatusCode: http.StatusOK, Code: "ratelimited"})
+
+		assert.AreEqual(t, errors.Is(err, ErrRateLimited), true)
+	})
+
+	t.Run("should not match ErrRateLimited on other errors", func(t *testing.T) {
+		err := error(&Error{StatusCode: http.StatusOK, Code: "channel_not_found"})
+
+		assert.AreEqual(t, errors.Is(err, ErrRateLimited), false)
+	})
+
+	t.Run("should expose the provider details", func(t *testing.T) {
+		err := &Error{StatusCode: http.StatusOK, Code: "channel_not_found"}
+
+		assert.AreEqual(t, err.HTTPStatusCode(), http.StatusOK)
+		assert.AreEqual(t, err.ProviderCode(), "channel_not_found")
+	})
+}
+
+func TestErrorCodes(t *testing.T) {
+	t.Run("should match the errors of the Slack error codes", func(t *testing.T) {
+		notFound := error(&Error{StatusCode: http.StatusOK, Code: "message_not_found"})
+		cantUpdate := error(&Error{StatusCode: http.StatusOK, Code: "cant_update_message"})
+		cantDelete := error(&Error{StatusCode: http.StatusOK, Code: "cant_delete_message"})
+
+		assert.AreEqual(t, errors.Is(notFound, ErrMessageNotFound), true)
+		assert.AreEqual(t, errors.Is(cantUpdate, ErrCantUpdateMessage), true)
+		assert.AreEqual(t, errors.Is(cantDelete, ErrCantDeleteMessage), true)
+		assert.AreEqual(t, errors.Is(notFound, ErrCantUpdateMessage), false)
+	})
+}
@@ -0,0 +1,240 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+// DefaultMaxUploadSize is the maximum size of an uploaded file, as documented by Slack.
+const DefaultMaxUploadSize = 1 << 30
+
+// MaxBufferedUploadSize is the maximum size of a file of unknown size,
+// which is read in memory to measure it before the upload.
+const MaxBufferedUploadSize = 10 << 20
+
+// File is a file to upload to Slack, e.g. a log or a CSV report.
+// Reader is the content of the file (required) and Filename its name (required).
+// Size is the size of the content in bytes. When it is set, the content is streamed
+// and the upload fails if the reader holds more or fewer bytes. When it is zero,
+// the content is read in memory to measure it, up to MaxBufferedUploadSize.
+// SnippetType sets the syntax of text snippets, e.g. "python" or "csv".
+// The file is shared in Channel, replying in the thread of ThreadTS if set,
+// with InitialComment as message. Without a channel the file is private to the app.
+type File struct {
+	Reader         io.Reader
+	Filename       string
+	Title          string
+	AltText        string
+	SnippetType    string
+	Channel        string
+	ThreadTS       string
+	InitialComment string
+	Size           int64
+}
+
+// UploadedFile is a file uploaded to Slack.
+type UploadedFile struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// uploadURLResponse is the response of files.getUploadURLExternal.
+type uploadURLResponse struct {
+	UploadURL string `json:"upload_url"`
+	FileID    string `json:"file_id"`
+}
+
+// completeUploadResponse is the response of files.completeUploadExternal.
+type completeUploadResponse struct {
+	Files []UploadedFile `json:"files"`
+}
+
+// Upload uploads the file with the external upload flow of Slack:
+// it gets an upload URL, streams the content to it, then completes the upload,
+// sharing the file in the channel of the file, if any.
+// The content is never retried, since the reader cannot be read twice.
+// Doc: https://api.slack.com/messaging/files#uploading_files
+func (s *Slack) Upload(ctx context.Context, file File) (UploadedFile, error) {
+	content, size, err := s.fileContent(file)
+	if err != nil {
+		return UploadedFile{}, err
+	}
+
+	var target uploadURLResponse
+	err = s.call(ctx, "files.getUploadURLExternal", uploadURLForm(file, size), &target)
+	if err != nil {
+		return UploadedFile{}, err
+	}
+
+	if err := s.uploadContent(ctx, target.UploadURL, content, size); err != nil {
+		return UploadedFile{}, err
+	}
+
+	if file.Channel != "" && s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, file.Channel); err != nil {
+			return UploadedFile{}, err
+		}
+	}
+
+	form, err := completeUploadForm(target.FileID, file)
+	if err != nil {
+		return UploadedFile{}, err
+	}
+
+	var completed completeUploadResponse
+	if err := s.call(ctx, "files.completeUploadExternal", form, &completed); err != nil {
+		return UploadedFile{}, err
+	}
+	if len(completed.Files) == 0 {
+		return UploadedFile{ID: target.FileID, Title: file.Title}, nil
+	}
+	return completed.Files[0], nil
+}
+
+// fileContent validates the file and returns its content and size,
+// reading it in memory when the size is unknown.
+func (s *Slack) fileContent(file File) (io.Reader, int64, error) {
+	if file.Reader == nil {
+		return nil, 0, fmt.Errorf("missing file content")
+	}
+	if strings.TrimSpace(file.Filename) == "" {
+		return nil, 0, fmt.Errorf("missing filename")
+	}
+	if file.ThreadTS != "" && file.Channel == "" {
+		return nil, 0, fmt.Errorf("missing channel")
+	}
+
+	maxSize := s.MaxUploadSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxUploadSize
+	}
+
+	switch {
+	case file.Size < 0:
+		return nil, 0, fmt.Errorf("invalid file size")
+	case file.Size > maxSize:
+		return nil, 0, fmt.Errorf("file exceeds %d bytes", maxSize)
+	case file.Size > 0:
+		return &sizedReader{reader: file.Reader, size: file.Size}, file.Size, nil
+	}
+
+	limit := min(maxSize, MaxBufferedUploadSize)
+	content, err := io.ReadAll(io.LimitReader(file.Reader, limit+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading file: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, 0, fmt.Errorf("empty file")
+	}
+	if int64(len(content)) > limit {
+		if limit < maxSize {
+			return nil, 0, fmt.Errorf("file of unknown size exceeds %d bytes: set its size", limit)
+		}
+		return nil, 0, fmt.Errorf("file exceeds %d bytes", maxSize)
+	}
+	return bytes.NewReader(content), int64(len(content)), nil
+}
+
+// sizedReader streams exactly size bytes of the reader
+// and fails when the reader holds more or fewer bytes,
+// so a file is never uploaded truncated.
+type sizedReader struct {
+	reader io.Reader
+	size   int64
+	read   int64
+}
+
+func (r *sizedReader) Read(p []byte) (int, error) {
+	if r.read == r.size {
+		var extra [1]byte
+		n, err := io.ReadFull(r.reader, extra[:])
+		if n > 0 {
+			return 0, fmt.Errorf("file content exceeds its size of %d bytes", r.size)
+		}
+		if err != io.EOF {
+			return 0, fmt.Errorf("error reading file: %w", err)
+		}
+		return 0, io.EOF
+	}
+
+	n, err := r.reader.Read(p[:min(int64(len(p)), r.size-r.read)])
+	r.read += int64(n)
+	if err == io.EOF {
+		if r.read < r.size {
+			return n, fmt.Errorf("file content is shorter than its size of %d bytes", r.size)
+		}
+		err = nil
+	}
+	return n, err
+}
+
+// uploadURLForm is the payload of files.getUploadURLExternal,
+// which does not accept JSON.
+func uploadURLForm(file File, size int64) url.Values {
+	form := url.Values{
+		"filename": {file.Filename},
+		"length":   {strconv.FormatInt(size, 10)},
+	}
+	if file.AltText != "" {
+		form.Set("alt_txt", file.AltText)
+	}
+	if file.SnippetType != "" {
+		form.Set("snippet_type", file.SnippetType)
+	}
+	return form
+}
+
+// uploadContent streams the content to the upload URL.
+// The URL is signed, so it is treated as a secret in logs and errors.
+// Only the context bounds the upload, the timeout applies to API calls.
+func (s *Slack) uploadContent(
+	ctx context.Context,
+	uploadURL string,
+	content io.Reader,
+	size int64,
+) error {
+	resp, _, err := s.requester.Do(
+		ctx,
+		request.WithMethod(http.MethodPost),
+		request.WithSecretURL(uploadURL),
+		request.WithHeader("Content-Type", "application/octet-stream"),
+		request.WithBody(content, size),
+		request.WithTimeout(0),
+	)
+	if err != nil {
+		return fmt.Errorf("error uploading file: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &Error{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// completeUploadForm is the payload of files.completeUploadExternal.
+func completeUploadForm(fileID string, file File) (url.Values, error) {
+	files, err := json.Marshal([]UploadedFile{{ID: fileID, Title: file.Title}})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling files: %w", err)
+	}
+
+	form := url.Values{"files": {string(files)}}
+	if file.Channel != "" {
+		form.Set("channel_id", file.Channel)
+	}
+	if file.ThreadTS != "" {
+		form.Set("thread_ts", file.ThreadTS)
+	}
+	if file.InitialComment != "" {
+		form.Set("initial_comment", file.InitialComment)
+	}
+	return form, nil
+}
@@ -0,0 +1,247 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+// zeroReader is an endless reader of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestSlackUpload(t *testing.T) {
+	t.Run("should upload the file and share it in the thread", func(t *testing.T) {
+		var got []request.MockRequest
+		var content string
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = append(got, request.NewMockRequest(options...))
+				switch got[len(got)-1].URL {
+				case "https://slack.com/api/files.getUploadURLExternal":
+					return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true, ` +
+						`"upload_url": "https://files.slack.com/upload/v1/signed", "file_id": "F123"}`), nil
+				case "https://files.slack.com/upload/v1/signed":
+					data, _ := io.ReadAll(got[len(got)-1].Body)
+					content = string(data)
+					return &http.Response{StatusCode: http.StatusOK}, nil, nil
+				default:
+					return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true, ` +
+						`"files": [{"id": "F123", "title": "Report"}]}`), nil
+				}
+			},
+		}
+		messenger := &Slack{BaseURL: "https://slack.com/api", requester: mockRequester}
+
+		file, err := messenger.Upload(context.TODO(), File{
+			Reader:         strings.NewReader("id,status\n1,failed\n"),
+			Filename:       "report.csv",
+			Title:          "Report",
+			SnippetType:    "csv",
+			Channel:        "C123",
+			ThreadTS:       "1700000000.000100",
+			InitialComment: "Failed jobs",
+		})
+
+		uploadForm, _ := url.ParseQuery(string(got[0].Payload))
+		completeForm, _ := url.ParseQuery(string(got[2].Payload))
+		assert.IsNil(t, err)
+		assert.AreEqual(t, file, UploadedFile{ID: "F123", Title: "Report"})
+		assert.AreEqual(t, uploadForm.Get("filename"), "report.csv")
+		assert.AreEqual(t, uploadForm.Get("length"), "19")
+		assert.AreEqual(t, uploadForm.Get("snippet_type"), "csv")
+		assert.AreEqual(t, got[1].SecretURL, true)
+		assert.AreEqual(t, got[1].BodySize, int64(19))
+		assert.AreEqual(t, content, "id,status\n1,failed\n")
+		assert.AreEqual(t, got[2].URL, "https://slack.com/api/files.completeUploadExternal")
+		assert.AreEqual(t, completeForm.Get("files"), `[{"id":"F123","title":"Report"}]`)
+		assert.AreEqual(t, completeForm.Get("channel_id"), "C123")
+		assert.AreEqual(t, completeForm.Get("thread_ts"), "1700000000.000100")
+		assert.AreEqual(t, completeForm.Get("initial_comment"), "Failed jobs")
+	})
+
+	t.Run("should stream files of known size", func(t *testing.T) {
+		var forms []url.Values
+		var content string
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				if got.Body != nil {
+					data, _ := io.ReadAll(got.Body)
+					content = string(data)
+					return &http.Response{StatusCode: http.StatusOK}, nil, nil
+				}
+				form, _ := url.ParseQuery(string(got.Payload))
+				forms = append(forms, form)
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true, ` +
+					`"upload_url": "https://files.slack.com/upload/v1/signed", "file_id": "F123"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("panic: runtime error"),
+			Filename: "app.log",
+			Size:     20,
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, forms[0].Get("length"), "20")
+		assert.AreEqual(t, content, "panic: runtime error")
+		assert.AreEqual(t, forms[1].Has("channel_id"), false)
+	})
+
+	t.Run("should return error when the content does not match its size", func(t *testing.T) {
+		completed := false
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				if got.Body != nil {
+					_, err := io.ReadAll(got.Body)
+					return nil, nil, err
+				}
+				completed = strings.HasSuffix(got.URL, "files.completeUploadExternal")
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true, ` +
+					`"upload_url": "https://files.slack.com/upload/v1/signed", "file_id": "F123"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, longErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("panic: runtime error\nextra"),
+			Filename: "app.log",
+			Size:     20,
+		})
+		_, shortErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("panic"),
+			Filename: "app.log",
+			Size:     20,
+		})
+
+		assert.AreEqualErrs(
+			t,
+			longErr,
+			errors.New("error uploading file: file content exceeds its size of 20 bytes"),
+		)
+		assert.AreEqualErrs(
+			t,
+			shortErr,
+			errors.New("error uploading file: file content is shorter than its size of 20 bytes"),
+		)
+		assert.AreEqual(t, completed, false)
+	})
+
+	t.Run("should return error when a file of unknown size exceeds the buffer", func(t *testing.T) {
+		messenger := &Slack{}
+
+		_, err := messenger.Upload(context.TODO(), File{
+			Reader:   io.LimitReader(zeroReader{}, MaxBufferedUploadSize+1),
+			Filename: "app.log",
+		})
+
+		assert.AreEqualErrs(
+			t,
+			err,
+			errors.New("file of unknown size exceeds 10485760 bytes: set its size"),
+		)
+	})
+
+	t.Run("should return error when the file exceeds the maximum size", func(t *testing.T) {
+		calls := 0
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				calls++
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{MaxUploadSize: 4, requester: mockRequester}
+
+		_, knownErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("12345"),
+			Filename: "app.log",
+			Size:     5,
+		})
+		_, unknownErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("12345"),
+			Filename: "app.log",
+		})
+
+		assert.AreEqualErrs(t, knownErr, errors.New("file exceeds 4 bytes"))
+		assert.AreEqualErrs(t, unknownErr, errors.New("file exceeds 4 bytes"))
+		assert.AreEqual(t, calls, 0)
+	})
+
+	t.Run("should return error when the file is invalid", func(t *testing.T) {
+		messenger := &Slack{}
+
+		_, readerErr := messenger.Upload(context.TODO(), File{Filename: "app.log"})
+		_, nameErr := messenger.Upload(context.TODO(), File{Reader: strings.NewReader("log")})
+		_, emptyErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader(""),
+			Filename: "app.log",
+		})
+		_, threadErr := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("log"),
+			Filename: "app.log",
+			ThreadTS: "1700000000.000100",
+		})
+
+		assert.AreEqualErrs(t, readerErr, errors.New("missing file content"))
+		assert.AreEqualErrs(t, nameErr, errors.New("missing filename"))
+		assert.AreEqualErrs(t, emptyErr, errors.New("empty file"))
+		assert.AreEqualErrs(t, threadErr, errors.New("missing channel"))
+	})
+
+	t.Run("should not complete the upload when the content is rejected", func(t *testing.T) {
+		completed := false
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				if got.Body != nil {
+					return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, nil
+				}
+				completed = strings.HasSuffix(got.URL, "files.completeUploadExternal")
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true, ` +
+					`"upload_url": "https://files.slack.com/upload/v1/signed", "file_id": "F123"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("log"),
+			Filename: "app.log",
+		})
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: status-code: 503"))
+		assert.AreEqual(t, completed, false)
+	})
+
+	t.Run("should return error when Slack rejects the upload", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "invalid_auth"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.Upload(context.TODO(), File{
+			Reader:   strings.NewReader("log"),
+			Filename: "app.log",
+		})
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: invalid_auth"))
+	})
+}
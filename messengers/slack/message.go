@@ -0,0 +1,102 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+// updateMessage is the payload of chat.update.
+type updateMessage struct {
+	Channel string           `json:"channel"`
+	TS      string           `json:"ts"`
+	Text    string           `json:"text,omitempty"`
+	Content []map[string]any `json:"blocks"`
+}
+
+// deleteMessage is the payload of chat.delete.
+type deleteMessage struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// Update replaces the text and blocks of the referenced message,
+// e.g. to mark an alert as resolved. The channel and thread of the message are ignored.
+// A message without blocks removes the blocks of the referenced message.
+// errors.Is(err, ErrMessageNotFound) and errors.Is(err, ErrCantUpdateMessage)
+// detect messages that do not exist or cannot be updated.
+// Doc: https://api.slack.com/methods/chat.update
+func (s *Slack) Update(ctx context.Context, ref MessageRef, message Message) (MessageRef, error) {
+	if err := validateRef(ref); err != nil {
+		return MessageRef{}, err
+	}
+
+	if s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, ref.Channel); err != nil {
+			return MessageRef{}, err
+		}
+	}
+
+	// Slack keeps the previous blocks when blocks are omitted, an empty list removes them.
+	content := message.Content
+	if content == nil {
+		content = []map[string]any{}
+	}
+
+	var response Response
+	err := s.call(ctx, "chat.update", updateMessage{
+		Channel: ref.Channel,
+		TS:      ref.TS,
+		Text:    message.Text,
+		Content: content,
+	}, &response)
+	if err != nil {
+		return MessageRef{}, err
+	}
+	return MessageRef{Channel: response.Channel, TS: response.TS}, nil
+}
+
+// UpdateNotification replaces the referenced message with the notification,
+// rendered as with Notify.
+func (s *Slack) UpdateNotification(
+	ctx context.Context,
+	ref MessageRef,
+	n nofy.Notification,
+) (MessageRef, error) {
+	message, err := s.notification(n)
+	if err != nil {
+		return MessageRef{}, err
+	}
+
+	return s.Update(ctx, ref, message)
+}
+
+// Delete deletes the referenced message.
+// errors.Is(err, ErrMessageNotFound) and errors.Is(err, ErrCantDeleteMessage)
+// detect messages that do not exist or cannot be deleted.
+// Doc: https://api.slack.com/methods/chat.delete
+func (s *Slack) Delete(ctx context.Context, ref MessageRef) error {
+	if err := validateRef(ref); err != nil {
+		return err
+	}
+
+	if s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, ref.Channel); err != nil {
+			return err
+		}
+	}
+
+	return s.call(ctx, "chat.delete", deleteMessage{Channel: ref.Channel, TS: ref.TS}, nil)
+}
+
+func validateRef(ref MessageRef) error {
+	if strings.TrimSpace(ref.Channel) == "" {
+		return fmt.Errorf("missing channel")
+	}
+	if strings.TrimSpace(ref.TS) == "" {
+		return fmt.Errorf("missing ts")
+	}
+	return nil
+}
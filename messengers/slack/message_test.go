@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+var alertRef = MessageRef{Channel: "C123", TS: "1700000000.000100"}
+
+func TestSlackUpdate(t *testing.T) {
+	t.Run("should update the referenced message", func(t *testing.T) {
+		var got request.MockRequest
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = request.NewMockRequest(options...)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000100"}`), nil
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			Token:     "test-token",
+			requester: mockRequester,
+		}
+
+		ref, err := messenger.Update(context.TODO(), alertRef, Message{
+			Channel:  "ignored",
+			ThreadTS: "ignored",
+			Text:     "RESOLVED",
+			Content:  []map[string]any{{"type": "divider"}},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ref, alertRef)
+		assert.AreEqual(t, got.URL, "https://slack.com/api/chat.update")
+		assert.AreEqual(
+			t,
+			string(got.Payload),
+			`{"channel":"C123","ts":"1700000000.000100","text":"RESOLVED",`+
+				`"blocks":[{"type":"divider"}]}`,
+		)
+	})
+
+	t.Run("should remove the blocks when updating with text only", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000100"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.Update(context.TODO(), alertRef, Message{Text: "RESOLVED"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(
+			t,
+			string(payload),
+			`{"channel":"C123","ts":"1700000000.000100","text":"RESOLVED","blocks":[]}`,
+		)
+	})
+
+	t.Run("should update the referenced message with a notification", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000100"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.UpdateNotification(context.TODO(), alertRef, nofy.Notification{
+			Title: "RESOLVED: Database unreachable",
+		})
+
+		var sent updateMessage
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.TS, alertRef.TS)
+		assert.AreEqual(t, sent.Text, "RESOLVED: Database unreachable")
+		assert.AreEqual(t, len(sent.Content), 1)
+	})
+
+	t.Run("should match ErrMessageNotFound when the message does not exist", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "message_not_found"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		_, err := messenger.Update(context.TODO(), alertRef, Message{Text: "RESOLVED"})
+
+		assert.AreEqual(t, errors.Is(err, ErrMessageNotFound), true)
+	})
+
+	t.Run("should return error when the reference is incomplete", func(t *testing.T) {
+		messenger := &Slack{}
+
+		_, channelErr := messenger.Update(context.TODO(), MessageRef{TS: "1"}, Message{})
+		_, tsErr := messenger.Update(context.TODO(), MessageRef{Channel: "C123"}, Message{})
+
+		assert.AreEqualErrs(t, channelErr, errors.New("missing channel"))
+		assert.AreEqualErrs(t, tsErr, errors.New("missing ts"))
+	})
+}
+
+func TestSlackDelete(t *testing.T) {
+	t.Run("should delete the referenced message", func(t *testing.T) {
+		var got request.MockRequest
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = request.NewMockRequest(options...)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000100"}`), nil
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			requester: mockRequester,
+		}
+
+		err := messenger.Delete(context.TODO(), alertRef)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.URL, "https://slack.com/api/chat.delete")
+		assert.AreEqual(t, string(got.Payload), `{"channel":"C123","ts":"1700000000.000100"}`)
+	})
+
+	t.Run("should match ErrCantDeleteMessage when the message cannot be deleted", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "cant_delete_message"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		err := messenger.Delete(context.TODO(), alertRef)
+
+		assert.AreEqual(t, errors.Is(err, ErrCantDeleteMessage), true)
+		assert.AreEqualErrs(t, err, errors.New("error sending message: cant_delete_message"))
+	})
+}
@@ -0,0 +1,257 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+// Slack documented limits for blocks.
+// Doc: https://api.slack.com/reference/block-kit/blocks
+const (
+	maxHeaderLength  = 150
+	maxSectionLength = 3000
+	maxFieldLength   = 2000
+	maxFields        = 10
+	maxBlocks        = 50
+)
+
+var severityEmojis = map[nofy.Severity]string{
+	nofy.SeverityInfo:     ":information_source:",
+	nofy.SeverityWarning:  ":warning:",
+	nofy.SeverityError:    ":x:",
+	nofy.SeverityCritical: ":rotating_light:",
+}
+
+// Renderer converts a notification into Slack blocks, e.g. with a template.
+type Renderer func(n nofy.Notification) ([]map[string]any, error)
+
+// Notify renders the notification into blocks and sends it to the configured channel or user.
+// Attachments are not supported by chat.postMessage and are ignored.
+func (s *Slack) Notify(ctx context.Context, n nofy.Notification) error {
+	_, err := s.PostNotification(ctx, n)
+	return err
+}
+
+// PostNotification sends the notification as Notify does
+// and returns a reference to the posted message.
+func (s *Slack) PostNotification(ctx context.Context, n nofy.Notification) (MessageRef, error) {
+	message, err := s.notification(n)
+	if err != nil {
+		return MessageRef{}, err
+	}
+
+	return s.deliver(ctx, message)
+}
+
+// notification validates and renders the notification into a message.
+func (s *Slack) notification(n nofy.Notification) (Message, error) {
+	if err := n.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	blocks, err := s.render(n)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return s.message(fallbackText(n), blocks), nil
+}
+
+// message creates a message to the configured channel and thread.
+func (s *Slack) message(text string, blocks []map[string]any) Message {
+	return Message{
+		Channel:        s.Message.Channel,
+		ThreadTS:       s.Message.ThreadTS,
+		User:           s.Message.User,
+		ReplyBroadcast: s.Message.ReplyBroadcast,
+		Text:           text,
+		Content:        blocks,
+	}
+}
+
+// render converts the notification with the renderer, if any, or with Render.
+func (s *Slack) render(n nofy.Notification) ([]map[string]any, error) {
+	if s.Renderer == nil {
+		return Render(n), nil
+	}
+
+	blocks, err := s.Renderer(n)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering notification: %w", err)
+	}
+	return blocks, nil
+}
+
+// Render converts a notification into Slack blocks.
+// The title is rendered as a header prefixed by an emoji for the severity,
+// the body as a markdown section, the fields as section fields and the links as a context block.
+func Render(n nofy.Notification) []map[string]any {
+	blocks := make([]map[string]any, 0)
+
+	if title := strings.TrimSpace(n.Title); title != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "header",
+			"text": map[string]any{
+				"type":  "plain_text",
+				"text":  truncate(severityEmojis[n.Severity]+" "+title, maxHeaderLength),
+				"emoji": true,
+			},
+		})
+	}
+
+	if body := strings.TrimSpace(n.Body); body != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{
+				"type": "mrkdwn",
+				"text": truncate(body, maxSectionLength),
+			},
+		})
+	}
+
+	for start := 0; start < len(n.Fields); start += maxFields {
+		end := min(start+maxFields, len(n.Fields))
+		fields := make([]map[string]any, 0, end-start)
+		for _, field := range n.Fields[start:end] {
+			fields = append(fields, map[string]any{
+				"type": "mrkdwn",
+				"text": truncate(fmt.Sprintf("*%s*\n%s", field.Name, field.Value), maxFieldLength),
+			})
+		}
+		blocks = append(blocks, map[string]any{
+			"type":   "section",
+			"fields": fields,
+		})
+	}
+
+	if len(n.Links) > 0 {
+		links := make([]string, 0, len(n.Links))
+		for _, link := range n.Links {
+			links = append(links, fmt.Sprintf("<%s|%s>", link.URL, link.Text))
+		}
+		blocks = append(blocks, map[string]any{
+			"type": "context",
+			"elements": []map[string]any{
+				{
+					"type": "mrkdwn",
+					"text": truncate(strings.Join(links, " • "), maxSectionLength),
+				},
+			},
+		})
+	}
+
+	return blocks
+}
+
+// NotifyDigest sends several notifications as a single message to the configured channel,
+// rendering each notification as with Notify, separated by dividers.
+func (s *Slack) NotifyDigest(ctx context.Context, notifications []nofy.Notification) error {
+	message, err := s.digest(notifications)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.deliver(ctx, message)
+	return err
+}
+
+// digest validates and renders the notifications into a single message.
+func (s *Slack) digest(notifications []nofy.Notification) (Message, error) {
+	if len(notifications) == 0 {
+		return Message{}, fmt.Errorf("missing notifications")
+	}
+
+	rendered := make([][]map[string]any, 0, len(notifications))
+	for _, n := range notifications {
+		if err := n.Validate(); err != nil {
+			return Message{}, err
+		}
+		blocks, err := s.render(n)
+		if err != nil {
+			return Message{}, err
+		}
+		rendered = append(rendered, blocks)
+	}
+
+	return s.message(digestTitle(notifications), renderDigest(notifications, rendered)), nil
+}
+
+// RenderDigest converts several notifications into the blocks of a single message.
+// A header counts the notifications and each notification follows a divider.
+// Notifications that do not fit in a message are counted in a last context block.
+func RenderDigest(notifications []nofy.Notification) []map[string]any {
+	rendered := make([][]map[string]any, 0, len(notifications))
+	for _, n := range notifications {
+		rendered = append(rendered, Render(n))
+	}
+	return renderDigest(notifications, rendered)
+}
+
+// renderDigest groups the blocks rendered for every notification into a single message.
+func renderDigest(notifications []nofy.Notification, rendered [][]map[string]any) []map[string]any {
+	severity := nofy.SeverityInfo
+	for _, n := range notifications {
+		severity = max(severity, n.Severity)
+	}
+
+	title := severityEmojis[severity] + " " + digestTitle(notifications)
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{
+				"type":  "plain_text",
+				"text":  truncate(title, maxHeaderLength),
+				"emoji": true,
+			},
+		},
+	}
+
+	for i, notificationBlocks := range rendered {
+		// A block is kept for the count of the remaining notifications, unless this is the last one.
+		limit := maxBlocks
+		if i < len(rendered)-1 {
+			limit--
+		}
+		if len(blocks)+1+len(notificationBlocks) > limit {
+			blocks = append(blocks, map[string]any{
+				"type": "context",
+				"elements": []map[string]any{
+					{
+						"type": "mrkdwn",
+						"text": fmt.Sprintf("…and %d more", len(notifications)-i),
+					},
+				},
+			})
+			break
+		}
+
+		blocks = append(blocks, map[string]any{"type": "divider"})
+		blocks = append(blocks, notificationBlocks...)
+	}
+
+	return blocks
+}
+
+func digestTitle(notifications []nofy.Notification) string {
+	return fmt.Sprintf("%d notifications", len(notifications))
+}
+
+// fallbackText returns the text displayed in push notifications.
+func fallbackText(n nofy.Notification) string {
+	if strings.TrimSpace(n.Title) != "" {
+		return n.Title
+	}
+	return truncate(n.Body, maxSectionLength)
+}
+
+// truncate shortens text to at most limit characters.
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,293 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+func TestSlackNotify(t *testing.T) {
+	t.Run("should render and send notification to the configured channel", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message:   Message{Channel: "test-channel"},
+			BaseURL:   "https://slack.com/api",
+			Timeout:   5 * time.Second,
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{
+			Title: "Deploy finished",
+			Body:  "Version *1.2.3* is live",
+		})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.Channel, "test-channel", "Expected configured channel")
+		assert.AreEqual(t, sent.Text, "Deploy finished", "Expected fallback text")
+		assert.AreEqual(t, len(sent.Content), 2, "Expected header and body blocks")
+	})
+
+	t.Run("should return error when notification is empty", func(t *testing.T) {
+		messenger := &Slack{
+			Message:   Message{Channel: "test-channel"},
+			requester: nil,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{})
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+	})
+
+	t.Run("should return error when sending notification fails", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "channel_not_found"}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message:   Message{Channel: "test-channel"},
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: channel_not_found"))
+	})
+
+	t.Run("should post notifications in the configured thread", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000200"}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message: Message{
+				Channel:        "C123",
+				ThreadTS:       "1700000000.000100",
+				ReplyBroadcast: true,
+			},
+			requester: mockRequester,
+		}
+
+		ref, err := messenger.PostNotification(context.TODO(), nofy.Notification{Title: "Resolved"})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ref, MessageRef{Channel: "C123", TS: "1700000000.000200"})
+		assert.AreEqual(t, sent.ThreadTS, "1700000000.000100")
+		assert.AreEqual(t, sent.ReplyBroadcast, true)
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("should render title as header with severity emoji", func(t *testing.T) {
+		blocks := Render(nofy.Notification{Title: "Disk full", Severity: nofy.SeverityCritical})
+
+		assert.AreEqual(t, len(blocks), 1)
+		assert.AreEqual(t, blocks[0]["type"], "header")
+		assert.AreEqual(
+			t,
+			blocks[0]["text"].(map[string]any)["text"],
+			":rotating_light: Disk full",
+		)
+	})
+
+	t.Run("should split fields into sections of at most 10 fields", func(t *testing.T) {
+		fields := make([]nofy.Field, 12)
+		for i := range fields {
+			fields[i] = nofy.Field{Name: "name", Value: "value"}
+		}
+
+		blocks := Render(nofy.Notification{Fields: fields})
+
+		assert.AreEqual(t, len(blocks), 2)
+		assert.AreEqual(t, len(blocks[0]["fields"].([]map[string]any)), 10)
+		assert.AreEqual(t, len(blocks[1]["fields"].([]map[string]any)), 2)
+		assert.AreEqual(
+			t,
+			blocks[0]["fields"].([]map[string]any)[0]["text"],
+			"*name*\nvalue",
+		)
+	})
+
+	t.Run("should render links as a context block", func(t *testing.T) {
+		blocks := Render(nofy.Notification{
+			Links: []nofy.Link{
+				{Text: "Dashboard", URL: "https://example.com/dashboard"},
+				{Text: "Runbook", URL: "https://example.com/runbook"},
+			},
+		})
+
+		assert.AreEqual(t, len(blocks), 1)
+		assert.AreEqual(t, blocks[0]["type"], "context")
+		assert.AreEqual(
+			t,
+			blocks[0]["elements"].([]map[string]any)[0]["text"],
+			"<https://example.com/dashboard|Dashboard> • <https://example.com/runbook|Runbook>",
+		)
+	})
+
+	t.Run("should truncate body to the section limit", func(t *testing.T) {
+		blocks := Render(nofy.Notification{Body: strings.Repeat("a", 4000)})
+
+		text := blocks[0]["text"].(map[string]any)["text"].(string)
+		assert.AreEqual(t, len([]rune(text)), maxSectionLength)
+	})
+}
+
+func TestSlackNotifyDigest(t *testing.T) {
+	t.Run("should send the notifications as a single message", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message:   Message{Channel: "test-channel"},
+			BaseURL:   "https://slack.com/api",
+			requester: mockRequester,
+		}
+
+		err := messenger.NotifyDigest(context.TODO(), []nofy.Notification{
+			{Title: "Disk full"},
+			{Title: "Memory high", Body: "92%"},
+		})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.Channel, "test-channel")
+		assert.AreEqual(t, sent.Text, "2 notifications")
+		assert.AreEqual(t, len(sent.Content), 6, "Expected header, dividers and notification blocks")
+	})
+
+	t.Run("should return error when a notification is empty", func(t *testing.T) {
+		messenger := &Slack{Message: Message{Channel: "test-channel"}}
+
+		err := messenger.NotifyDigest(context.TODO(), []nofy.Notification{{Title: "Disk full"}, {}})
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+	})
+
+	t.Run("should return error when there are no notifications", func(t *testing.T) {
+		messenger := &Slack{Message: Message{Channel: "test-channel"}}
+
+		err := messenger.NotifyDigest(context.TODO(), nil)
+
+		assert.AreEqualErrs(t, err, errors.New("missing notifications"))
+	})
+}
+
+func TestRenderDigest(t *testing.T) {
+	t.Run("should render a header with the highest severity", func(t *testing.T) {
+		blocks := RenderDigest([]nofy.Notification{
+			{Title: "Disk full", Severity: nofy.SeverityWarning},
+			{Title: "Database down", Severity: nofy.SeverityCritical},
+		})
+
+		header := blocks[0]["text"].(map[string]any)["text"]
+		assert.AreEqual(t, header, ":rotating_light: 2 notifications")
+		assert.AreEqual(t, blocks[1]["type"], "divider")
+		assert.AreEqual(t, blocks[3]["type"], "divider")
+	})
+
+	t.Run("should count the notifications that do not fit in a message", func(t *testing.T) {
+		notifications := make([]nofy.Notification, 30)
+		for i := range notifications {
+			notifications[i] = nofy.Notification{Title: "Disk full", Body: "92%"}
+		}
+
+		blocks := RenderDigest(notifications)
+
+		last := blocks[len(blocks)-1]["elements"].([]map[string]any)[0]["text"]
+		assert.AreEqual(t, len(blocks), 50)
+		assert.AreEqual(t, last, "…and 14 more")
+	})
+
+	t.Run("should use every block when the last notification fits", func(t *testing.T) {
+		notifications := make([]nofy.Notification, 15)
+		for i := range notifications {
+			notifications[i] = nofy.Notification{Title: "Disk full", Body: "92%"}
+		}
+		notifications = append(notifications, nofy.Notification{
+			Title:  "Memory high",
+			Body:   "92%",
+			Fields: []nofy.Field{{Name: "Host", Value: "api-1"}},
+		})
+
+		blocks := RenderDigest(notifications)
+
+		assert.AreEqual(t, len(blocks), 50)
+		assert.AreEqual(t, blocks[len(blocks)-1]["type"], "section")
+	})
+}
+
+func TestSlackRenderer(t *testing.T) {
+	t.Run("should render notifications with the renderer", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message: Message{Channel: "test-channel"},
+			Renderer: func(n nofy.Notification) ([]map[string]any, error) {
+				return []map[string]any{{"type": "divider"}}, nil
+			},
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		var sent Message
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.Content, []map[string]any{{"type": "divider"}})
+	})
+
+	t.Run("should return error when rendering fails", func(t *testing.T) {
+		messenger := &Slack{
+			Message: Message{Channel: "test-channel"},
+			Renderer: func(n nofy.Notification) ([]map[string]any, error) {
+				return nil, errors.New("missing template")
+			},
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		assert.AreEqualErrs(t, err, errors.New("error rendering notification: missing template"))
+	})
+}
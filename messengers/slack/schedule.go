@@ -0,0 +1,178 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+// maxScheduleHorizon is how far in the future Slack accepts to schedule a message.
+const maxScheduleHorizon = 120 * 24 * time.Hour
+
+// ScheduledMessage is a message scheduled to be posted to Slack.
+// ID is the scheduled_message_id used to delete the message before it is posted.
+type ScheduledMessage struct {
+	PostAt    time.Time
+	CreatedAt time.Time
+	ID        string
+	Channel   string
+	Text      string
+}
+
+// scheduleMessage is the payload of chat.scheduleMessage.
+type scheduleMessage struct {
+	Message
+	PostAt int64 `json:"post_at"`
+}
+
+// deleteScheduledMessage is the payload of chat.deleteScheduledMessage.
+type deleteScheduledMessage struct {
+	Channel string `json:"channel"`
+	ID      string `json:"scheduled_message_id"`
+}
+
+// scheduledMessage is a scheduled message as listed by chat.scheduledMessages.list.
+type scheduledMessage struct {
+	ID          string `json:"id"`
+	Channel     string `json:"channel_id"`
+	Text        string `json:"text"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+}
+
+// Schedule schedules the message to be posted at postAt,
+// which must be in the future and at most 120 days away.
+// Doc: https://api.slack.com/methods/chat.scheduleMessage
+func (s *Slack) Schedule(
+	ctx context.Context,
+	message Message,
+	postAt time.Time,
+) (ScheduledMessage, error) {
+	if strings.TrimSpace(message.Channel) == "" {
+		return ScheduledMessage{}, fmt.Errorf("missing channel")
+	}
+	if err := s.validatePostAt(postAt); err != nil {
+		return ScheduledMessage{}, err
+	}
+
+	if s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, message.Channel); err != nil {
+			return ScheduledMessage{}, err
+		}
+	}
+
+	// The post time of the response is ignored: Slack documents it as a string,
+	// and the message is posted at the requested time anyway.
+	var response struct {
+		Channel string `json:"channel"`
+		ID      string `json:"scheduled_message_id"`
+	}
+	err := s.call(ctx, "chat.scheduleMessage", scheduleMessage{
+		Message: message,
+		PostAt:  postAt.Unix(),
+	}, &response)
+	if err != nil {
+		return ScheduledMessage{}, err
+	}
+
+	return ScheduledMessage{
+		ID:      response.ID,
+		Channel: response.Channel,
+		Text:    message.Text,
+		PostAt:  time.Unix(postAt.Unix(), 0),
+	}, nil
+}
+
+// ScheduleNotification schedules the notification, rendered as with Notify,
+// to be posted to the configured channel at postAt.
+func (s *Slack) ScheduleNotification(
+	ctx context.Context,
+	n nofy.Notification,
+	postAt time.Time,
+) (ScheduledMessage, error) {
+	message, err := s.notification(n)
+	if err != nil {
+		return ScheduledMessage{}, err
+	}
+
+	return s.Schedule(ctx, message, postAt)
+}
+
+// ListScheduled returns the messages scheduled in the channel that are not posted yet,
+// or in every channel when channel is empty.
+// Doc: https://api.slack.com/methods/chat.scheduledMessages.list
+func (s *Slack) ListScheduled(ctx context.Context, channel string) ([]ScheduledMessage, error) {
+	scheduled := make([]ScheduledMessage, 0)
+	cursor := ""
+	for {
+		form := url.Values{}
+		if channel != "" {
+			form.Set("channel", channel)
+		}
+		if cursor != "" {
+			form.Set("cursor", cursor)
+		}
+
+		var response struct {
+			ScheduledMessages []scheduledMessage `json:"scheduled_messages"`
+			ResponseMetadata  struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := s.call(ctx, "chat.scheduledMessages.list", form, &response); err != nil {
+			return nil, err
+		}
+
+		for _, message := range response.ScheduledMessages {
+			scheduled = append(scheduled, ScheduledMessage{
+				ID:        message.ID,
+				Channel:   message.Channel,
+				Text:      message.Text,
+				PostAt:    time.Unix(message.PostAt, 0),
+				CreatedAt: time.Unix(message.DateCreated, 0),
+			})
+		}
+
+		cursor = response.ResponseMetadata.NextCursor
+		if cursor == "" {
+			return scheduled, nil
+		}
+	}
+}
+
+// DeleteScheduled cancels the scheduled message before it is posted.
+// errors.Is(err, ErrMessageNotFound) detects messages already posted or deleted.
+// Doc: https://api.slack.com/methods/chat.deleteScheduledMessage
+func (s *Slack) DeleteScheduled(ctx context.Context, channel, id string) error {
+	if strings.TrimSpace(channel) == "" {
+		return fmt.Errorf("missing channel")
+	}
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("missing scheduled message id")
+	}
+
+	return s.call(ctx, "chat.deleteScheduledMessage", deleteScheduledMessage{
+		Channel: channel,
+		ID:      id,
+	}, nil)
+}
+
+// validatePostAt checks that postAt is within the horizon accepted by Slack.
+func (s *Slack) validatePostAt(postAt time.Time) error {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+
+	if !postAt.After(now) {
+		return fmt.Errorf("post time must be in the future")
+	}
+	if postAt.Sub(now) > maxScheduleHorizon {
+		return fmt.Errorf("post time exceeds %d days", int(maxScheduleHorizon.Hours()/24))
+	}
+	return nil
+}
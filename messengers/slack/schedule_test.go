@@ -0,0 +1,232 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+var scheduleNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSlackSchedule(t *testing.T) {
+	t.Run("should schedule the message and return its id", func(t *testing.T) {
+		var got request.MockRequest
+		postAt := scheduleNow.Add(24 * time.Hour)
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = request.NewMockRequest(options...)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "scheduled_message_id": "Q123", ` +
+					`"post_at": 1717329600}`), nil
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			requester: mockRequester,
+			now:       func() time.Time { return scheduleNow },
+		}
+
+		scheduled, err := messenger.Schedule(context.TODO(), Message{
+			Channel: "C123",
+			Text:    "Maintenance starts in 1 hour",
+		}, postAt)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, scheduled.ID, "Q123")
+		assert.AreEqual(t, scheduled.Channel, "C123")
+		assert.AreEqual(t, scheduled.PostAt.Equal(postAt), true)
+		assert.AreEqual(t, got.URL, "https://slack.com/api/chat.scheduleMessage")
+		assert.AreEqual(
+			t,
+			string(got.Payload),
+			`{"channel":"C123","text":"Maintenance starts in 1 hour","post_at":1717329600}`,
+		)
+	})
+
+	t.Run("should return the requested post time", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "scheduled_message_id": "Q123", ` +
+					`"post_at": "1717329600"}`), nil
+			},
+		}
+		messenger := &Slack{
+			requester: mockRequester,
+			now:       func() time.Time { return scheduleNow },
+		}
+		postAt := scheduleNow.Add(24*time.Hour + 500*time.Millisecond)
+
+		scheduled, err := messenger.Schedule(context.TODO(), Message{
+			Channel: "C123",
+			Text:    "Maintenance starts in 1 hour",
+		}, postAt)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, scheduled.ID, "Q123")
+		assert.AreEqual(t, scheduled.PostAt.Equal(postAt.Truncate(time.Second)), true)
+	})
+
+	t.Run("should schedule notifications to the configured channel", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "scheduled_message_id": "Q123"}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message:   Message{Channel: "C123"},
+			requester: mockRequester,
+			now:       func() time.Time { return scheduleNow },
+		}
+
+		_, err := messenger.ScheduleNotification(
+			context.TODO(),
+			nofy.Notification{Title: "Maintenance tonight"},
+			scheduleNow.Add(time.Hour),
+		)
+
+		var sent scheduleMessage
+		_ = json.Unmarshal(payload, &sent)
+		assert.IsNil(t, err)
+		assert.AreEqual(t, sent.Channel, "C123")
+		assert.AreEqual(t, sent.Text, "Maintenance tonight")
+		assert.AreEqual(t, sent.PostAt, scheduleNow.Add(time.Hour).Unix())
+	})
+
+	t.Run("should validate the post time locally", func(t *testing.T) {
+		messenger := &Slack{now: func() time.Time { return scheduleNow }}
+		message := Message{Channel: "C123", Text: "Maintenance"}
+
+		_, pastErr := messenger.Schedule(context.TODO(), message, scheduleNow)
+		_, farErr := messenger.Schedule(
+			context.TODO(),
+			message,
+			scheduleNow.Add(120*24*time.Hour+time.Second),
+		)
+		_, channelErr := messenger.Schedule(
+			context.TODO(),
+			Message{Text: "Maintenance"},
+			scheduleNow.Add(time.Hour),
+		)
+
+		assert.AreEqualErrs(t, pastErr, errors.New("post time must be in the future"))
+		assert.AreEqualErrs(t, farErr, errors.New("post time exceeds 120 days"))
+		assert.AreEqualErrs(t, channelErr, errors.New("missing channel"))
+	})
+}
+
+func TestSlackListScheduled(t *testing.T) {
+	t.Run("should list the scheduled messages of every page", func(t *testing.T) {
+		var forms []url.Values
+		pages := []string{
+			`{"ok": true, "scheduled_messages": [{"id": "Q1", "channel_id": "C123", ` +
+				`"post_at": 1717329600, "date_created": 1717243200, "text": "first"}], ` +
+				`"response_metadata": {"next_cursor": "page-2"}}`,
+			`{"ok": true, "scheduled_messages": [{"id": "Q2", "channel_id": "C123", ` +
+				`"post_at": 1717416000, "date_created": 1717243200, "text": "second"}], ` +
+				`"response_metadata": {"next_cursor": ""}}`,
+		}
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				form, _ := url.ParseQuery(string(request.NewMockRequest(options...).Payload))
+				forms = append(forms, form)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(pages[len(forms)-1]), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		scheduled, err := messenger.ListScheduled(context.TODO(), "C123")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(scheduled), 2)
+		assert.AreEqual(t, scheduled[0].ID, "Q1")
+		assert.AreEqual(t, scheduled[1].Text, "second")
+		assert.AreEqual(t, scheduled[1].PostAt.Unix(), int64(1717416000))
+		assert.AreEqual(t, scheduled[1].CreatedAt.Unix(), int64(1717243200))
+		assert.AreEqual(t, forms[0].Get("channel"), "C123")
+		assert.AreEqual(t, forms[1].Get("cursor"), "page-2")
+	})
+
+	t.Run("should return error when listing fails", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "invalid_channel"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		scheduled, err := messenger.ListScheduled(context.TODO(), "C123")
+
+		assert.IsNil(t, scheduled)
+		assert.AreEqualErrs(t, err, errors.New("error sending message: invalid_channel"))
+	})
+}
+
+func TestSlackDeleteScheduled(t *testing.T) {
+	t.Run("should delete the scheduled message", func(t *testing.T) {
+		var got request.MockRequest
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = request.NewMockRequest(options...)
+				return &http.Response{StatusCode: http.StatusOK}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			requester: mockRequester,
+		}
+
+		err := messenger.DeleteScheduled(context.TODO(), "C123", "Q123")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.URL, "https://slack.com/api/chat.deleteScheduledMessage")
+		assert.AreEqual(
+			t,
+			string(got.Payload),
+			`{"channel":"C123","scheduled_message_id":"Q123"}`,
+		)
+	})
+
+	t.Run("should match ErrMessageNotFound when the message was already posted", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "invalid_scheduled_message_id"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		err := messenger.DeleteScheduled(context.TODO(), "C123", "Q123")
+
+		assert.AreEqual(t, errors.Is(err, ErrMessageNotFound), true)
+	})
+
+	t.Run("should return error when the channel or id is missing", func(t *testing.T) {
+		messenger := &Slack{}
+
+		channelErr := messenger.DeleteScheduled(context.TODO(), "", "Q123")
+		idErr := messenger.DeleteScheduled(context.TODO(), "C123", "")
+
+		assert.AreEqualErrs(t, channelErr, errors.New("missing channel"))
+		assert.AreEqualErrs(t, idErr, errors.New("missing scheduled message id"))
+	})
+}
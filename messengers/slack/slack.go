@@ -4,49 +4,92 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
-	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
 	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
 const Timeout = 5000
 
+// HTTPClient is the client used to send requests to Slack.
+// *http.Client satisfies this interface.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
 // Slack is a client to send messages to Slack.
 type Slack struct {
-	requester request.Requester
-	URL       string
-	Token     string
-	Message   Message
-	Timeout   time.Duration
+	requester     request.Requester
+	Client        HTTPClient
+	BaseURL       string
+	Token         string
+	Message       Message
+	Timeout       time.Duration
+	Logger        *slog.Logger
+	Limiter       *ratelimit.Limiter
+	Renderer      Renderer
+	Retry         request.RetryPolicy
+	UserEmail     string
+	users         idCache
+	conversations idCache
+	now           func() time.Time
+	MaxUploadSize int64
+	Ephemeral     bool
 }
 
 // Message is the message to send to Slack.
+// Channel is the ID of the channel to post to (required).
+// Content is the list of blocks of the message (required).
+// Text is the fallback text shown in notifications and by clients that cannot render blocks.
+// ThreadTS is the timestamp of the parent message to reply to in its thread.
+// ReplyBroadcast also shows a thread reply in the channel.
+// User is the ID of the user an ephemeral message is shown to.
 type Message struct {
-	Channel string           `json:"channel"`
-	Content []map[string]any `json:"blocks"`
+	Channel        string           `json:"channel"`
+	Text           string           `json:"text,omitempty"`
+	ThreadTS       string           `json:"thread_ts,omitempty"`
+	User           string           `json:"user,omitempty"`
+	Content        []map[string]any `json:"blocks,omitempty"`
+	ReplyBroadcast bool             `json:"reply_broadcast,omitempty"`
+}
+
+// InThread returns a copy of the message replying in the thread of the referenced message.
+func (m Message) InThread(ref MessageRef) Message {
+	m.Channel = ref.Channel
+	m.ThreadTS = ref.TS
+	return m
+}
+
+// MessageRef references a message posted to Slack.
+// Channel is the ID of the channel the message was posted to.
+// TS is the timestamp of the message, used to reply in its thread.
+type MessageRef struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
 }
 
 // Response is the response from Slack.
 // OK is true if the message was sent successfully.
 // Error contains the error message if the message could not be sent.
+// Channel and TS reference the posted message.
 type Response struct {
-	Error string `json:"error,omitempty"`
-	OK    bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	TS      string `json:"ts,omitempty"`
+	OK      bool   `json:"ok"`
 }
 
 type Option func(*Slack)
 
 // NewSlackMessenger creates a new Slack client.
-func NewSlackMessenger(options ...Option) (nofy.Messenger, error) {
+func NewSlackMessenger(options ...Option) (*Slack, error) {
 	slack := &Slack{
-		URL:     "https://slack.com/api/chat.postMessage",
+		BaseURL: "https://slack.com/api",
 		Timeout: Timeout * time.Millisecond,
 	}
 
@@ -59,7 +102,15 @@ func NewSlackMessenger(options ...Option) (nofy.Messenger, error) {
 		return nil, err
 	}
 
-	slack.requester = request.NewRequester()
+	if slack.Client == nil {
+		slack.Client = request.NewHTTPClient(slack.Timeout)
+	}
+
+	slack.requester = request.NewRequester(
+		request.WithClient(slack.Client),
+		request.WithRetry(slack.Retry),
+		request.WithLogger(slack.Logger),
+	)
 
 	return slack, nil
 }
@@ -68,18 +119,28 @@ func validate(slack *Slack) error {
 	if strings.TrimSpace(slack.Token) == "" {
 		return fmt.Errorf("missing token")
 	}
+	baseURL, err := url.Parse(slack.BaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return fmt.Errorf("invalid base url")
+	}
 	if slack.Timeout == 0 {
 		return fmt.Errorf("missing timeout")
 	}
-	if strings.TrimSpace(slack.Message.Channel) == "" {
-		return fmt.Errorf("missing channel")
+	hasUser := slack.Message.User != "" || slack.UserEmail != ""
+	if slack.Ephemeral && !hasUser {
+		return fmt.Errorf("missing user")
 	}
-	if slack.Message.Content == nil {
-		return fmt.Errorf("missing message")
+	if strings.TrimSpace(slack.Message.Channel) == "" && (!hasUser || slack.Ephemeral) {
+		return fmt.Errorf("missing channel")
 	}
 	return nil
 }
 
+// Name returns the name of the messenger.
+func (s *Slack) Name() string {
+	return "slack"
+}
+
 // WithToken sets the Token for the Slack client.
 func WithToken(token string) Option {
 	return func(s *Slack) {
@@ -94,6 +155,77 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithChannel sets the channel the Slack client posts to.
+func WithChannel(channel string) Option {
+	return func(s *Slack) {
+		s.Message.Channel = channel
+	}
+}
+
+// WithUser sends the messages to the user with the given ID:
+// as direct messages, or as ephemeral messages in the channel with WithEphemeral.
+func WithUser(userID string) Option {
+	return func(s *Slack) {
+		s.Message.User = userID
+	}
+}
+
+// WithUserEmail sends the messages to the user with the given email, as WithUser does.
+// The user is looked up once, then cached.
+func WithUserEmail(email string) Option {
+	return func(s *Slack) {
+		s.UserEmail = email
+	}
+}
+
+// WithEphemeral shows the messages only to the user set with WithUser or WithUserEmail,
+// in the configured channel. Ephemeral messages cannot be updated or threaded.
+func WithEphemeral() Option {
+	return func(s *Slack) {
+		s.Ephemeral = true
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by the Slack client,
+// e.g. to route requests through a proxy or to use custom TLS roots.
+// The timeout is still enforced on every request.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(s *Slack) {
+		s.Client = client
+	}
+}
+
+// WithBaseURL sets the base URL of the Slack API (default: https://slack.com/api).
+func WithBaseURL(baseURL string) Option {
+	return func(s *Slack) {
+		s.BaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRetry sets the retry policy for the Slack client.
+// By default a failed message is not retried.
+func WithRetry(policy request.RetryPolicy) Option {
+	return func(s *Slack) {
+		s.Retry = policy
+	}
+}
+
+// WithLogger sets the logger used to report the requests of the Slack client.
+// The token is redacted from the logs.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Slack) {
+		s.Logger = logger
+	}
+}
+
+// WithRateLimit sets the rate limiter of the Slack client, keyed by channel.
+// Depending on the limiter, messages over the limit wait or fail with a *ratelimit.LimitError.
+func WithRateLimit(limiter *ratelimit.Limiter) Option {
+	return func(s *Slack) {
+		s.Limiter = limiter
+	}
+}
+
 // WithMessage sets the Message for the Slack client.
 func WithMessage(message Message) Option {
 	return func(s *Slack) {
@@ -101,50 +233,121 @@ func WithMessage(message Message) Option {
 	}
 }
 
+// WithThreadTS sets the timestamp of the parent message the Slack client replies to,
+// e.g. the TS of the MessageRef returned by Post for the first alert of an incident.
+func WithThreadTS(threadTS string) Option {
+	return func(s *Slack) {
+		s.Message.ThreadTS = threadTS
+	}
+}
+
+// WithReplyBroadcast also shows the thread replies in the channel.
+func WithReplyBroadcast() Option {
+	return func(s *Slack) {
+		s.Message.ReplyBroadcast = true
+	}
+}
+
+// WithMaxUploadSize sets the maximum size of an uploaded file in bytes (default: 1GB).
+func WithMaxUploadSize(size int64) Option {
+	return func(s *Slack) {
+		s.MaxUploadSize = size
+	}
+}
+
+// WithRenderer sets how notifications are converted into blocks (default: Render).
+func WithRenderer(renderer Renderer) Option {
+	return func(s *Slack) {
+		s.Renderer = renderer
+	}
+}
+
 // Send sends a message with blocks to a Slack channel.
 // Block messages are used to create rich messages with elements.
 // Doc: https://api.slack.com/reference/messaging/blocks
 // Playground: https://app.slack.com/block-kit-builder
 func (s *Slack) Send(ctx context.Context) error {
-	msg, err := json.Marshal(s.Message)
-	if err != nil {
-		return fmt.Errorf("error marshaling message: %w", err)
+	if s.Message.Content == nil {
+		return fmt.Errorf("missing message")
+	}
+
+	_, err := s.deliver(ctx, s.Message)
+	return err
+}
+
+// Post sends the given message to Slack and returns a reference to the posted message,
+// e.g. to reply in its thread with Message.InThread.
+// Doc: https://api.slack.com/methods/chat.postMessage
+func (s *Slack) Post(ctx context.Context, message Message) (MessageRef, error) {
+	if s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, message.Channel); err != nil {
+			return MessageRef{}, err
+		}
 	}
 
-	httpClient := http.DefaultClient
+	var response Response
+	if err := s.call(ctx, "chat.postMessage", message, &response); err != nil {
+		return MessageRef{}, err
+	}
+	return MessageRef{Channel: response.Channel, TS: response.TS}, nil
+}
+
+// call sends the payload to the Slack API method and decodes the response into out, if set.
+// The payload is sent as JSON, or form encoded when it is url.Values,
+// for the methods that do not accept JSON.
+func (s *Slack) call(ctx context.Context, method string, payload, out any) error {
+	contentType := "application/json"
+	var msg []byte
+	if form, ok := payload.(url.Values); ok {
+		contentType = "application/x-www-form-urlencoded"
+		msg = []byte(form.Encode())
+	} else {
+		var err error
+		msg, err = json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling message: %w", err)
+		}
+	}
 
 	resp, body, err := s.requester.Do(
 		ctx,
 		request.WithMethod(http.MethodPost),
-		request.WithURL(s.URL),
+		request.WithURL(s.BaseURL+"/"+method),
 		request.WithHeader("Authorization", "Bearer "+s.Token),
-		request.WithHeader("Content-Type", "application/json"),
+		request.WithHeader("Content-Type", contentType),
 		request.WithHeader("Accept", "application/json"),
-		request.WithClient(httpClient),
 		request.WithPayload(msg),
+		request.WithTimeout(s.Timeout),
 	)
 	if err != nil {
 		return fmt.Errorf("error sending request: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"error sending message: status-code: %d",
-			resp.StatusCode,
-		)
+		return &Error{StatusCode: resp.StatusCode}
 	}
 
-	var slackResponse Response
+	var slackResponse struct {
+		Error string `json:"error"`
+		OK    bool   `json:"ok"`
+	}
 	err = json.Unmarshal(body, &slackResponse)
 	if err != nil {
 		return fmt.Errorf("error unmarshalling response: %w", err)
 	}
 
 	if !slackResponse.OK {
-		return fmt.Errorf(
-			"error sending message: %s",
-			slackResponse.Error,
-		)
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Code:       slackResponse.Error,
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error unmarshalling response: %w", err)
 	}
 	return nil
 }
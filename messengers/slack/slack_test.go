@@ -3,11 +3,14 @@ package slack
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
 	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
@@ -102,22 +105,55 @@ func TestNewSlackMessenger(t *testing.T) {
 		)
 	})
 
-	t.Run("should return error when message content is missing", func(t *testing.T) {
+	t.Run("should return error when base URL is invalid", func(t *testing.T) {
 		_, err := NewSlackMessenger(
 			WithToken("test-token"),
-			WithTimeout(5*time.Second),
-			WithMessage(
-				Message{
-					Channel: "test-channel",
-				}),
+			WithBaseURL("localhost"),
+			WithChannel("test-channel"),
 		)
 
 		assert.AreEqualErrs(
 			t,
 			err,
-			errors.New("missing message"),
-			"Expected missing message error",
+			errors.New("invalid base url"),
+			"Expected invalid base URL error",
+		)
+	})
+
+	t.Run("should send message through the given HTTP client and base URL", func(t *testing.T) {
+		var got *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		}))
+		defer server.Close()
+		messenger, err := NewSlackMessenger(
+			WithToken("test-token"),
+			WithBaseURL(server.URL+"/api"),
+			WithHTTPClient(server.Client()),
+			WithMessage(Message{
+				Channel: "test-channel",
+				Content: []map[string]any{{"type": "divider"}},
+			}),
+		)
+		assert.IsNil(t, err)
+
+		err = messenger.Send(context.TODO())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.URL.Path, "/api/chat.postMessage")
+		assert.AreEqual(t, got.Header.Get("Authorization"), "Bearer test-token")
+	})
+
+	t.Run("should create Slack messenger without message content", func(t *testing.T) {
+		messenger, err := NewSlackMessenger(
+			WithToken("test-token"),
+			WithTimeout(5*time.Second),
+			WithChannel("test-channel"),
 		)
+
+		assert.IsNil(t, err)
+		assert.IsNotNil(t, messenger)
 	})
 }
 
@@ -145,12 +181,102 @@ func TestSlackOptions(t *testing.T) {
 			"Expected timeout to be 10s",
 		)
 	})
+
+	t.Run("should set retry policy correctly with WithRetry option", func(t *testing.T) {
+		slack := &Slack{}
+		WithRetry(request.DefaultRetryPolicy())(slack)
+
+		assert.AreEqual(
+			t,
+			slack.Retry.MaxAttempts,
+			3,
+			"Expected retry policy to be set",
+		)
+	})
+
+	t.Run("should set HTTP client correctly with WithHTTPClient option", func(t *testing.T) {
+		slack := &Slack{}
+		client := &http.Client{}
+		WithHTTPClient(client)(slack)
+
+		assert.AreEqual(
+			t,
+			slack.Client,
+			HTTPClient(client),
+			"Expected HTTP client to be set",
+		)
+	})
+
+	t.Run("should set base URL correctly with WithBaseURL option", func(t *testing.T) {
+		slack := &Slack{}
+		WithBaseURL("http://localhost:8080/api/")(slack)
+
+		assert.AreEqual(
+			t,
+			slack.BaseURL,
+			"http://localhost:8080/api",
+			"Expected base URL without trailing slash",
+		)
+	})
+
+	t.Run("should set logger correctly with WithLogger option", func(t *testing.T) {
+		slack := &Slack{}
+		logger := slog.Default()
+		WithLogger(logger)(slack)
+
+		assert.AreEqual(
+			t,
+			slack.Logger,
+			logger,
+			"Expected logger to be set",
+		)
+	})
+
+	t.Run("should set rate limiter correctly with WithRateLimit option", func(t *testing.T) {
+		slack := &Slack{}
+		limiter := ratelimit.New(time.Second, 1)
+		WithRateLimit(limiter)(slack)
+
+		assert.AreEqual(
+			t,
+			slack.Limiter,
+			limiter,
+			"Expected rate limiter to be set",
+		)
+	})
+
+	t.Run("should set channel correctly with WithChannel option", func(t *testing.T) {
+		slack := &Slack{}
+		WithChannel("test-channel")(slack)
+
+		assert.AreEqual(
+			t,
+			slack.Message.Channel,
+			"test-channel",
+			"Expected channel to be 'test-channel'",
+		)
+	})
+
+	t.Run("should set thread correctly with thread options", func(t *testing.T) {
+		slack := &Slack{}
+		WithThreadTS("1700000000.000100")(slack)
+		WithReplyBroadcast()(slack)
+
+		assert.AreEqual(t, slack.Message.ThreadTS, "1700000000.000100")
+		assert.AreEqual(t, slack.Message.ReplyBroadcast, true)
+	})
 }
 
 func TestSlackSend(t *testing.T) {
 	t.Run("should send message successfully", func(t *testing.T) {
 		mockRequester := &request.MockRequester{
 			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				assert.AreEqual(
+					t,
+					request.NewMockRequest(options...).Timeout,
+					5*time.Second,
+					"Expected timeout to be applied",
+				)
 				return &http.Response{
 					StatusCode: http.StatusOK,
 				}, []byte(`{"ok": true}`), nil
@@ -170,7 +296,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "https://slack.com/api/chat.postMessage",
+			BaseURL:   "https://slack.com/api",
 			Timeout:   5 * time.Second,
 			requester: mockRequester,
 		}
@@ -180,6 +306,53 @@ func TestSlackSend(t *testing.T) {
 		assert.IsNil(t, err)
 	})
 
+	t.Run("should return error when message content is missing", func(t *testing.T) {
+		messenger := &Slack{
+			Message:   Message{Channel: "test-channel"},
+			BaseURL:   "https://slack.com/api",
+			Timeout:   5 * time.Second,
+			requester: nil,
+		}
+
+		err := messenger.Send(context.TODO())
+
+		assert.AreEqualErrs(
+			t,
+			err,
+			errors.New("missing message"),
+			"Expected missing message error",
+		)
+	})
+
+	t.Run("should return error when the channel is over its rate limit", func(t *testing.T) {
+		calls := 0
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				calls++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true}`), nil
+			},
+		}
+		messenger := &Slack{
+			Message: Message{
+				Channel: "test-channel",
+				Content: []map[string]any{{"type": "divider"}},
+			},
+			Limiter:   ratelimit.New(time.Hour, 1, ratelimit.WithFailFast()),
+			requester: mockRequester,
+		}
+
+		first := messenger.Send(context.TODO())
+		second := messenger.Send(context.TODO())
+
+		assert.IsNil(t, first)
+		var limitErr *ratelimit.LimitError
+		assert.AreEqual(t, errors.As(second, &limitErr), true, "Expected rate limit error")
+		assert.AreEqual(t, limitErr.Key, "test-channel", "Expected channel as key")
+		assert.AreEqual(t, calls, 1, "Expected a single request")
+	})
+
 	t.Run("should return error when marshalling message fails", func(t *testing.T) {
 		msg := Message{
 			Channel: "test-channel",
@@ -195,7 +368,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "https://slack.com/api/chat.postMessage",
+			BaseURL:   "https://slack.com/api",
 			Timeout:   5 * time.Second,
 			requester: nil,
 		}
@@ -232,7 +405,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "https://slack.com/api/chat.postMessage",
+			BaseURL:   "https://slack.com/api",
 			Timeout:   5 * time.Second,
 			requester: mockRequester,
 		}
@@ -271,7 +444,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "",
+			BaseURL:   "",
 			Timeout:   5 * time.Second,
 			requester: mockRequester,
 		}
@@ -310,7 +483,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "",
+			BaseURL:   "",
 			Timeout:   5 * time.Second,
 			requester: mockRequester,
 		}
@@ -351,7 +524,7 @@ func TestSlackSend(t *testing.T) {
 		}
 		messenger := &Slack{
 			Message:   msg,
-			URL:       "",
+			BaseURL:   "",
 			Timeout:   5 * time.Second,
 			requester: mockRequester,
 		}
@@ -367,3 +540,71 @@ func TestSlackSend(t *testing.T) {
 		)
 	})
 }
+
+func TestSlackPost(t *testing.T) {
+	t.Run("should return a reference to the posted message", func(t *testing.T) {
+		var url string
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				url = request.NewMockRequest(options...).URL
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000100"}`), nil
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			requester: mockRequester,
+		}
+
+		ref, err := messenger.Post(context.TODO(), Message{
+			Channel: "alerts",
+			Content: []map[string]any{{"type": "divider"}},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ref, MessageRef{Channel: "C123", TS: "1700000000.000100"})
+		assert.AreEqual(t, url, "https://slack.com/api/chat.postMessage")
+	})
+
+	t.Run("should reply in the thread of a message", func(t *testing.T) {
+		var payload []byte
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = request.NewMockRequest(options...).Payload
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": true, "channel": "C123", "ts": "1700000000.000200"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+		parent := MessageRef{Channel: "C123", TS: "1700000000.000100"}
+		reply := Message{Text: "Resolved", ReplyBroadcast: true}
+
+		_, err := messenger.Post(context.TODO(), reply.InThread(parent))
+
+		assert.IsNil(t, err)
+		assert.AreEqual(
+			t,
+			string(payload),
+			`{"channel":"C123","text":"Resolved","thread_ts":"1700000000.000100",`+
+				`"reply_broadcast":true}`,
+		)
+	})
+
+	t.Run("should return error when the response is not OK", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "thread_not_found"}`), nil
+			},
+		}
+		messenger := &Slack{requester: mockRequester}
+
+		ref, err := messenger.Post(context.TODO(), Message{Channel: "C123", ThreadTS: "1"})
+
+		assert.AreEqual(t, ref, MessageRef{})
+		assert.AreEqualErrs(t, err, errors.New("error sending message: thread_not_found"))
+	})
+}
@@ -0,0 +1,140 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// idCache caches the IDs resolved from the Slack API, e.g. users by email.
+// The zero value is ready to use.
+type idCache struct {
+	ids map[string]string
+	mu  sync.Mutex
+}
+
+func (c *idCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id, ok := c.ids[key]
+	return id, ok
+}
+
+func (c *idCache) set(key, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ids == nil {
+		c.ids = make(map[string]string)
+	}
+	c.ids[key] = id
+}
+
+// deliver posts the message to the configured user, if any, or to its channel:
+// as an ephemeral message with WithEphemeral, otherwise as a direct message.
+func (s *Slack) deliver(ctx context.Context, message Message) (MessageRef, error) {
+	if message.User == "" && s.UserEmail == "" {
+		return s.Post(ctx, message)
+	}
+
+	if message.User == "" {
+		user, err := s.LookupUserByEmail(ctx, s.UserEmail)
+		if err != nil {
+			return MessageRef{}, err
+		}
+		message.User = user
+	}
+
+	if s.Ephemeral {
+		return s.PostEphemeral(ctx, message)
+	}
+
+	channel, err := s.OpenConversation(ctx, message.User)
+	if err != nil {
+		return MessageRef{}, err
+	}
+	message.Channel = channel
+	message.User = ""
+	return s.Post(ctx, message)
+}
+
+// PostEphemeral shows the message only to its user, in its channel.
+// The user must be a member of the channel.
+// Doc: https://api.slack.com/methods/chat.postEphemeral
+func (s *Slack) PostEphemeral(ctx context.Context, message Message) (MessageRef, error) {
+	if strings.TrimSpace(message.Channel) == "" {
+		return MessageRef{}, fmt.Errorf("missing channel")
+	}
+	if strings.TrimSpace(message.User) == "" {
+		return MessageRef{}, fmt.Errorf("missing user")
+	}
+
+	if s.Limiter != nil {
+		if err := s.Limiter.Wait(ctx, message.Channel); err != nil {
+			return MessageRef{}, err
+		}
+	}
+
+	var response struct {
+		MessageTS string `json:"message_ts"`
+	}
+	if err := s.call(ctx, "chat.postEphemeral", message, &response); err != nil {
+		return MessageRef{}, err
+	}
+	return MessageRef{Channel: message.Channel, TS: response.MessageTS}, nil
+}
+
+// OpenConversation opens a direct message with the user and returns the ID of its channel.
+// Channels are cached, so repeated messages to the user do not open it again.
+// Doc: https://api.slack.com/methods/conversations.open
+func (s *Slack) OpenConversation(ctx context.Context, userID string) (string, error) {
+	if strings.TrimSpace(userID) == "" {
+		return "", fmt.Errorf("missing user")
+	}
+	if channel, ok := s.conversations.get(userID); ok {
+		return channel, nil
+	}
+
+	var response struct {
+		Channel struct {
+			ID string `json:"id"`
+		} `json:"channel"`
+	}
+	err := s.call(ctx, "conversations.open", url.Values{"users": {userID}}, &response)
+	if err != nil {
+		return "", err
+	}
+
+	s.conversations.set(userID, response.Channel.ID)
+	return response.Channel.ID, nil
+}
+
+// LookupUserByEmail returns the ID of the user with the email.
+// Users are cached, so repeated messages to the user do not look it up again.
+// errors.Is(err, ErrUserNotFound) detects emails without a user.
+// Doc: https://api.slack.com/methods/users.lookupByEmail
+func (s *Slack) LookupUserByEmail(ctx context.Context, email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", fmt.Errorf("missing email")
+	}
+	if user, ok := s.users.get(email); ok {
+		return user, nil
+	}
+
+	var response struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	err := s.call(ctx, "users.lookupByEmail", url.Values{"email": {email}}, &response)
+	if err != nil {
+		return "", err
+	}
+
+	s.users.set(email, response.User.ID)
+	return response.User.ID, nil
+}
@@ -0,0 +1,185 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+func TestSlackDirectMessages(t *testing.T) {
+	t.Run("should send direct messages to the user", func(t *testing.T) {
+		var opened url.Values
+		var sent Message
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				switch got.URL {
+				case "https://slack.com/api/conversations.open":
+					opened, _ = url.ParseQuery(string(got.Payload))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "channel": {"id": "DU123"}}`), nil
+				default:
+					_ = json.Unmarshal(got.Payload, &sent)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "channel": "DU123", "ts": "1700000000.000100"}`), nil
+				}
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			Message:   Message{User: "U123"},
+			requester: mockRequester,
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "You are on call"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, opened.Get("users"), "U123")
+		assert.AreEqual(t, sent.Channel, "DU123")
+		assert.AreEqual(t, sent.User, "")
+	})
+
+	t.Run("should look up the user by email once", func(t *testing.T) {
+		calls := make(map[string]int)
+		var lookup url.Values
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				calls[got.URL]++
+				switch got.URL {
+				case "https://slack.com/api/users.lookupByEmail":
+					lookup, _ = url.ParseQuery(string(got.Payload))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "user": {"id": "U123"}}`), nil
+				case "https://slack.com/api/conversations.open":
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "channel": {"id": "DU123"}}`), nil
+				default:
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "channel": "DU123", "ts": "1700000000.000100"}`), nil
+				}
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			UserEmail: "OnCall@example.com",
+			requester: mockRequester,
+		}
+
+		first := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+		second := messenger.Notify(context.TODO(), nofy.Notification{Title: "CPU high"})
+
+		assert.IsNil(t, first)
+		assert.IsNil(t, second)
+		assert.AreEqual(t, lookup.Get("email"), "oncall@example.com")
+		assert.AreEqual(t, calls["https://slack.com/api/users.lookupByEmail"], 1)
+		assert.AreEqual(t, calls["https://slack.com/api/conversations.open"], 1)
+		assert.AreEqual(t, calls["https://slack.com/api/chat.postMessage"], 2)
+	})
+
+	t.Run("should match ErrUserNotFound when no user has the email", func(t *testing.T) {
+		calls := 0
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				calls++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+				}, []byte(`{"ok": false, "error": "users_not_found"}`), nil
+			},
+		}
+		messenger := &Slack{UserEmail: "unknown@example.com", requester: mockRequester}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		assert.AreEqual(t, errors.Is(err, ErrUserNotFound), true)
+		assert.AreEqual(t, calls, 1, "Expected no message to be posted")
+	})
+}
+
+func TestSlackEphemeral(t *testing.T) {
+	t.Run("should show the message only to the user in the channel", func(t *testing.T) {
+		var urls []string
+		var sent Message
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got := request.NewMockRequest(options...)
+				urls = append(urls, got.URL)
+				switch got.URL {
+				case "https://slack.com/api/users.lookupByEmail":
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "user": {"id": "U123"}}`), nil
+				default:
+					_ = json.Unmarshal(got.Payload, &sent)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+					}, []byte(`{"ok": true, "message_ts": "1700000000.000200"}`), nil
+				}
+			},
+		}
+		messenger := &Slack{
+			BaseURL:   "https://slack.com/api",
+			UserEmail: "oncall@example.com",
+			Message:   Message{Channel: "C123"},
+			Ephemeral: true,
+			requester: mockRequester,
+		}
+
+		ref, err := messenger.PostNotification(context.TODO(), nofy.Notification{Title: "Ack?"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ref, MessageRef{Channel: "C123", TS: "1700000000.000200"})
+		assert.AreEqual(t, sent.Channel, "C123")
+		assert.AreEqual(t, sent.User, "U123")
+		assert.AreEqual(t, urls, []string{
+			"https://slack.com/api/users.lookupByEmail",
+			"https://slack.com/api/chat.postEphemeral",
+		})
+	})
+
+	t.Run("should return error when the user or channel is missing", func(t *testing.T) {
+		messenger := &Slack{}
+
+		_, channelErr := messenger.PostEphemeral(context.TODO(), Message{User: "U123"})
+		_, userErr := messenger.PostEphemeral(context.TODO(), Message{Channel: "C123"})
+
+		assert.AreEqualErrs(t, channelErr, errors.New("missing channel"))
+		assert.AreEqualErrs(t, userErr, errors.New("missing user"))
+	})
+}
+
+func TestValidateUser(t *testing.T) {
+	t.Run("should not require a channel for direct messages", func(t *testing.T) {
+		_, err := NewSlackMessenger(WithToken("test-token"), WithUser("U123"))
+
+		assert.IsNil(t, err)
+	})
+
+	t.Run("should require a channel and a user for ephemeral messages", func(t *testing.T) {
+		_, channelErr := NewSlackMessenger(
+			WithToken("test-token"),
+			WithUser("U123"),
+			WithEphemeral(),
+		)
+		_, userErr := NewSlackMessenger(
+			WithToken("test-token"),
+			WithChannel("C123"),
+			WithEphemeral(),
+		)
+
+		assert.AreEqualErrs(t, channelErr, errors.New("missing channel"))
+		assert.AreEqualErrs(t, userErr, errors.New("missing user"))
+	})
+}
@@ -0,0 +1,159 @@
+package slack
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+// Webhook is a client to send messages to a Slack incoming webhook.
+// The webhook URL is a secret: it is never logged nor reported in errors.
+// Doc: https://api.slack.com/messaging/webhooks
+type Webhook struct {
+	slack *Slack
+	url   string
+	key   string
+}
+
+// webhookMessage is the payload of an incoming webhook,
+// which always posts to the channel it was created for.
+type webhookMessage struct {
+	Text     string           `json:"text,omitempty"`
+	ThreadTS string           `json:"thread_ts,omitempty"`
+	Content  []map[string]any `json:"blocks,omitempty"`
+}
+
+// NewWebhookMessenger creates a new client for the Slack incoming webhook URL.
+// It accepts the options of NewSlackMessenger, except WithToken, WithChannel, WithBaseURL
+// and the user options, that do not apply to webhooks.
+// The rate limiter is keyed by a hash of the webhook URL.
+func NewWebhookMessenger(webhookURL string, options ...Option) (*Webhook, error) {
+	slack := &Slack{
+		Timeout: Timeout * time.Millisecond,
+	}
+
+	for _, opt := range options {
+		opt(slack)
+	}
+
+	err := validateWebhook(webhookURL, slack)
+	if err != nil {
+		return nil, err
+	}
+
+	if slack.Client == nil {
+		slack.Client = request.NewHTTPClient(slack.Timeout)
+	}
+
+	slack.requester = request.NewRequester(
+		request.WithClient(slack.Client),
+		request.WithRetry(slack.Retry),
+		request.WithLogger(slack.Logger),
+	)
+
+	sum := sha256.Sum256([]byte(webhookURL))
+	return &Webhook{
+		slack: slack,
+		url:   webhookURL,
+		key:   "webhook:" + hex.EncodeToString(sum[:4]),
+	}, nil
+}
+
+func validateWebhook(webhookURL string, slack *Slack) error {
+	if strings.TrimSpace(webhookURL) == "" {
+		return fmt.Errorf("missing webhook url")
+	}
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook url")
+	}
+	if slack.Timeout == 0 {
+		return fmt.Errorf("missing timeout")
+	}
+	return nil
+}
+
+// Name returns the name of the messenger.
+func (w *Webhook) Name() string {
+	return "slack-webhook"
+}
+
+// Send sends the configured message to the webhook.
+func (w *Webhook) Send(ctx context.Context) error {
+	if w.slack.Message.Content == nil {
+		return fmt.Errorf("missing message")
+	}
+
+	return w.Post(ctx, w.slack.Message)
+}
+
+// Post sends the given message to the webhook.
+// The channel of the message is ignored, webhooks post to the channel they were created for.
+func (w *Webhook) Post(ctx context.Context, message Message) error {
+	msg, err := json.Marshal(webhookMessage{
+		Text:     message.Text,
+		ThreadTS: message.ThreadTS,
+		Content:  message.Content,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %w", err)
+	}
+
+	if w.slack.Limiter != nil {
+		if err := w.slack.Limiter.Wait(ctx, w.key); err != nil {
+			return err
+		}
+	}
+
+	resp, body, err := w.slack.requester.Do(
+		ctx,
+		request.WithMethod(http.MethodPost),
+		request.WithSecretURL(w.url),
+		request.WithHeader("Content-Type", "application/json"),
+		request.WithPayload(msg),
+		request.WithTimeout(w.slack.Timeout),
+	)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+
+	// Webhooks answer with plain text: ok, or an error code such as invalid_payload.
+	code := strings.TrimSpace(string(body))
+	if resp.StatusCode == http.StatusOK && code == "ok" {
+		return nil
+	}
+	if strings.ContainsAny(code, " \n<{") {
+		code = ""
+	}
+	return &Error{StatusCode: resp.StatusCode, Code: code}
+}
+
+// Notify renders the notification into blocks and sends it to the webhook.
+func (w *Webhook) Notify(ctx context.Context, n nofy.Notification) error {
+	message, err := w.slack.notification(n)
+	if err != nil {
+		return err
+	}
+
+	return w.Post(ctx, message)
+}
+
+// NotifyDigest sends several notifications as a single message to the webhook,
+// rendered as with Slack.NotifyDigest.
+func (w *Webhook) NotifyDigest(ctx context.Context, notifications []nofy.Notification) error {
+	message, err := w.slack.digest(notifications)
+	if err != nil {
+		return err
+	}
+
+	return w.Post(ctx, message)
+}
@@ -0,0 +1,201 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
+	"github.com/lucasvillarinho/nofy/helpers/request"
+)
+
+func TestNewWebhookMessenger(t *testing.T) {
+	t.Run("should create webhook messenger without token and channel", func(t *testing.T) {
+		messenger, err := NewWebhookMessenger("https://hooks.slack.com/services/T000/B000/secret")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, messenger.Name(), "slack-webhook")
+	})
+
+	t.Run("should return error when the webhook url is invalid", func(t *testing.T) {
+		_, missingErr := NewWebhookMessenger(" ")
+		_, invalidErr := NewWebhookMessenger("hooks.slack.com/services")
+		_, timeoutErr := NewWebhookMessenger("https://hooks.slack.com/services", WithTimeout(0))
+
+		assert.AreEqualErrs(t, missingErr, errors.New("missing webhook url"))
+		assert.AreEqualErrs(t, invalidErr, errors.New("invalid webhook url"))
+		assert.AreEqualErrs(t, timeoutErr, errors.New("missing timeout"))
+	})
+}
+
+func TestWebhookSend(t *testing.T) {
+	t.Run("should post the message blocks to the webhook", func(t *testing.T) {
+		var got request.MockRequest
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				got = request.NewMockRequest(options...)
+				return &http.Response{StatusCode: http.StatusOK}, []byte("ok"), nil
+			},
+		}
+		messenger := &Webhook{
+			slack: &Slack{
+				Message: Message{
+					Channel: "ignored",
+					Text:    "Deploy finished",
+					Content: []map[string]any{{"type": "divider"}},
+				},
+				requester: mockRequester,
+			},
+			url: "https://hooks.slack.com/services/T000/B000/secret",
+		}
+
+		err := messenger.Send(context.TODO())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.URL, "https://hooks.slack.com/services/T000/B000/secret")
+		assert.AreEqual(t, got.SecretURL, true)
+		assert.AreEqual(
+			t,
+			string(got.Payload),
+			`{"text":"Deploy finished","blocks":[{"type":"divider"}]}`,
+		)
+	})
+
+	t.Run("should return error when message content is missing", func(t *testing.T) {
+		messenger, _ := NewWebhookMessenger("https://hooks.slack.com/services/T000/B000/secret")
+
+		err := messenger.Send(context.TODO())
+
+		assert.AreEqualErrs(t, err, errors.New("missing message"))
+	})
+
+	t.Run("should return the plain text error code of the webhook", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+				}, []byte("invalid_payload"), nil
+			},
+		}
+		messenger := &Webhook{slack: &Slack{requester: mockRequester}}
+
+		err := messenger.Post(context.TODO(), Message{Text: "Deploy finished"})
+
+		var slackErr *Error
+		assert.AreEqual(t, errors.As(err, &slackErr), true)
+		assert.AreEqual(t, slackErr.StatusCode, http.StatusBadRequest)
+		assert.AreEqualErrs(t, err, errors.New("error sending message: invalid_payload"))
+	})
+
+	t.Run("should ignore bodies that are not error codes", func(t *testing.T) {
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+				}, []byte("<html>Bad Gateway</html>"), nil
+			},
+		}
+		messenger := &Webhook{slack: &Slack{requester: mockRequester}}
+
+		err := messenger.Post(context.TODO(), Message{Text: "Deploy finished"})
+
+		assert.AreEqualErrs(t, err, errors.New("error sending message: status-code: 502"))
+	})
+
+	t.Run("should not expose the webhook url in logs and transport errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		messenger, _ := NewWebhookMessenger(
+			server.URL+"/services/T000/B000/secret",
+			WithHTTPClient(server.Client()),
+			WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+			}))),
+		)
+
+		err := messenger.Post(context.TODO(), Message{Text: "Deploy finished"})
+		server.Close()
+		transportErr := messenger.Post(context.TODO(), Message{Text: "Deploy finished"})
+
+		assert.IsNil(t, err)
+		assert.IsNotNil(t, transportErr)
+		assert.AreEqual(t, strings.Contains(buf.String(), "request completed"), true)
+		assert.AreEqual(t, strings.Contains(buf.String(), "secret"), false)
+		assert.AreEqual(t, strings.Contains(transportErr.Error(), "secret"), false)
+	})
+
+	t.Run("should rate limit the webhook without exposing its url", func(t *testing.T) {
+		messenger, _ := NewWebhookMessenger(
+			"https://hooks.slack.com/services/T000/B000/secret",
+			WithRateLimit(ratelimit.New(time.Hour, 1, ratelimit.WithFailFast())),
+		)
+		messenger.slack.requester = &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				return &http.Response{StatusCode: http.StatusOK}, []byte("ok"), nil
+			},
+		}
+
+		first := messenger.Post(context.TODO(), Message{Text: "first"})
+		second := messenger.Post(context.TODO(), Message{Text: "second"})
+
+		var limitErr *ratelimit.LimitError
+		assert.IsNil(t, first)
+		assert.AreEqual(t, errors.As(second, &limitErr), true)
+		assert.AreEqual(t, strings.HasPrefix(limitErr.Key, "webhook:"), true)
+		assert.AreEqual(t, strings.Contains(second.Error(), "secret"), false)
+	})
+}
+
+func TestWebhookNotify(t *testing.T) {
+	t.Run("should render the notification into blocks", func(t *testing.T) {
+		var payload string
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				payload = string(request.NewMockRequest(options...).Payload)
+				return &http.Response{StatusCode: http.StatusOK}, []byte("ok"), nil
+			},
+		}
+		messenger := &Webhook{
+			slack: &Slack{
+				Message:   Message{ThreadTS: "1700000000.000100"},
+				requester: mockRequester,
+			},
+		}
+
+		err := messenger.Notify(context.TODO(), nofy.Notification{Title: "Disk full"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, strings.Contains(payload, `"text":"Disk full"`), true)
+		assert.AreEqual(t, strings.Contains(payload, `"thread_ts":"1700000000.000100"`), true)
+		assert.AreEqual(t, strings.Contains(payload, `"channel"`), false)
+	})
+
+	t.Run("should send digests as a single message", func(t *testing.T) {
+		calls := 0
+		mockRequester := &request.MockRequester{
+			DoFunc: func(ctx context.Context, options ...request.Option) (*http.Response, []byte, error) {
+				calls++
+				return &http.Response{StatusCode: http.StatusOK}, []byte("ok"), nil
+			},
+		}
+		messenger := &Webhook{slack: &Slack{requester: mockRequester}}
+
+		err := messenger.NotifyDigest(context.TODO(), []nofy.Notification{
+			{Title: "Disk full"},
+			{Title: "CPU high"},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, calls, 1)
+	})
+}
@@ -0,0 +1,76 @@
+package nofy
+
+import "context"
+
+// Middleware wraps a messenger to add behavior around Send and Notify,
+// such as logging, metrics, retries, rate limiting or redaction.
+// Messengers returned by a middleware should implement Namer so that
+// results keep the name of the wrapped messenger.
+type Middleware func(Messenger) Messenger
+
+// Chain wraps the messenger with the middlewares.
+// The first middleware is the outermost one, so it is called first.
+func Chain(m Messenger, middlewares ...Middleware) Messenger {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		m = middlewares[i](m)
+	}
+	return m
+}
+
+// Use adds middlewares that wrap every messenger of Nofy when sending.
+// It is safe to call while sending: sends in flight keep the middlewares they started with.
+func (s *Nofy) Use(middlewares ...Middleware) {
+	s.registry.use(middlewares)
+}
+
+// WithMiddlewares adds middlewares that wrap every messenger of Nofy when sending.
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(s *Nofy) {
+		s.Use(middlewares...)
+	}
+}
+
+// Call describes a delivery seen by an Interceptor.
+// Messenger is the name the messenger is registered under in Nofy,
+// or its own name, see NameOf, when it is wrapped outside of Nofy.
+// Notification is nil for Send and points to the notification being
+// delivered for Notify; interceptors may modify it before calling next,
+// copying its slices and maps first since they are shared with the caller.
+type Call struct {
+	Notification *Notification
+	Messenger    string
+}
+
+// Interceptor is called around every Send and Notify of a messenger.
+// It must call next to continue the delivery.
+type Interceptor func(ctx context.Context, call Call, next func(context.Context) error) error
+
+// Intercept creates a middleware that calls the interceptor around both Send and Notify.
+func Intercept(interceptor Interceptor) Middleware {
+	return func(m Messenger) Messenger {
+		return &intercepted{
+			next:        m,
+			interceptor: interceptor,
+		}
+	}
+}
+
+type intercepted struct {
+	next        Messenger
+	interceptor Interceptor
+}
+
+func (i *intercepted) Name() string {
+	return NameOf(i.next)
+}
+
+func (i *intercepted) Send(ctx context.Context) error {
+	return i.interceptor(ctx, Call{Messenger: i.Name()}, i.next.Send)
+}
+
+func (i *intercepted) Notify(ctx context.Context, n Notification) error {
+	call := Call{Messenger: i.Name(), Notification: &n}
+	return i.interceptor(ctx, call, func(ctx context.Context) error {
+		return i.next.Notify(ctx, n)
+	})
+}
@@ -0,0 +1,145 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string, mu *sync.Mutex) Middleware {
+	return Intercept(func(ctx context.Context, _ Call, next func(context.Context) error) error {
+		mu.Lock()
+		*calls = append(*calls, name)
+		mu.Unlock()
+		return next(ctx)
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Run("should call middlewares from the first to the last", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := make([]string, 0)
+		m := &MockMessenger{sendFunc: func(_ context.Context) error {
+			calls = append(calls, "messenger")
+			return nil
+		}}
+
+		err := Chain(
+			m,
+			recordingMiddleware("first", &calls, &mu),
+			recordingMiddleware("second", &calls, &mu),
+		).Send(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, calls, []string{"first", "second", "messenger"})
+	})
+
+	t.Run("should return the messenger when there are no middlewares", func(t *testing.T) {
+		m := &MockMessenger{}
+
+		assert.AreEqual(t, Chain(m), Messenger(m))
+	})
+}
+
+func TestIntercept(t *testing.T) {
+	t.Run("should keep the name of the wrapped messenger", func(t *testing.T) {
+		m := Intercept(func(ctx context.Context, _ Call, next func(context.Context) error) error {
+			return next(ctx)
+		})(&MockMessenger{name: "slack"})
+
+		assert.AreEqual(t, NameOf(m), "slack")
+	})
+
+	t.Run("should describe Send calls without notification", func(t *testing.T) {
+		var got Call
+		m := Intercept(func(ctx context.Context, call Call, next func(context.Context) error) error {
+			got = call
+			return next(ctx)
+		})(&MockMessenger{name: "slack"})
+
+		err := m.Send(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.Messenger, "slack")
+		assert.IsNil(t, got.Notification)
+	})
+
+	t.Run("should let interceptors modify the notification", func(t *testing.T) {
+		var delivered Notification
+		m := Intercept(func(ctx context.Context, call Call, next func(context.Context) error) error {
+			call.Notification.Body = strings.ReplaceAll(call.Notification.Body, "s3cr3t", "[REDACTED]")
+			return next(ctx)
+		})(&MockMessenger{notifyFunc: func(_ context.Context, n Notification) error {
+			delivered = n
+			return nil
+		}})
+
+		err := m.Notify(context.Background(), Notification{Body: "password=s3cr3t"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, delivered.Body, "password=[REDACTED]")
+	})
+
+	t.Run("should return the error of the interceptor", func(t *testing.T) {
+		called := false
+		m := Intercept(func(_ context.Context, _ Call, _ func(context.Context) error) error {
+			return errors.New("unauthorized")
+		})(&MockMessenger{sendFunc: func(_ context.Context) error {
+			called = true
+			return nil
+		}})
+
+		err := m.Send(context.Background())
+
+		assert.AreEqualErrs(t, err, errors.New("unauthorized"))
+		assert.AreEqual(t, called, false, "Expected messenger not to be called")
+	})
+}
+
+func TestUse(t *testing.T) {
+	t.Run("should wrap every messenger when sending", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := make([]string, 0)
+		s := New(
+			WithMessengers(&MockMessenger{name: "slack"}, &MockMessenger{name: "resend"}),
+			WithMiddlewares(recordingMiddleware("logging", &calls, &mu)),
+		)
+		s.Use(recordingMiddleware("metrics", &calls, &mu))
+
+		result := s.NotifyAllResult(context.Background(), Notification{Title: "Disk full"})
+
+		assert.IsNil(t, result.Err())
+		assert.AreEqual(t, len(calls), 4)
+		assert.AreEqual(t, result.Results[0].Messenger, "slack")
+		assert.AreEqual(t, result.Results[1].Messenger, "resend")
+	})
+
+	t.Run("should add middlewares while sending", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := make([]string, 0)
+		s := New(WithMessengers(&MockMessenger{name: "slack"}))
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = s.SendAll(context.Background())
+			}()
+			go func() {
+				defer wg.Done()
+				s.Use(recordingMiddleware("metrics", &calls, &mu))
+			}()
+		}
+		wg.Wait()
+		calls = calls[:0]
+		err := s.SendAll(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(calls), 10)
+	})
+}
@@ -2,83 +2,237 @@ package nofy
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
+// Messenger delivers messages to a single service.
+// Send delivers the message the messenger was configured with.
+// Notify renders the notification into the service's native format and delivers it,
+// so a single configured messenger can deliver many different notifications.
 type Messenger interface {
 	Send(ctx context.Context) error
+	Notify(ctx context.Context, n Notification) error
 }
 
+// ErrSkipped is the error of messengers that were not called
+// because a previous messenger failed in stop on failure mode.
+var ErrSkipped = errors.New("skipped")
+
+// Nofy delivers through the messengers registered in it.
+// Messengers can be registered, removed, enabled and disabled at any time,
+// including while sending.
 type Nofy struct {
-	messengers []Messenger
+	logger         *slog.Logger
+	registry       registry
+	maxConcurrency int
+	stopOnFailure  bool
 }
 
-func New() *Nofy {
-	return &Nofy{
-		messengers: make([]Messenger, 0),
+type Option func(*Nofy)
+
+func New(options ...Option) *Nofy {
+	nofy := &Nofy{}
+
+	for _, opt := range options {
+		opt(nofy)
 	}
+
+	return nofy
 }
 
 func NewWithMessengers(messengers ...Messenger) *Nofy {
-	return &Nofy{
-		messengers: messengers,
+	return New(WithMessengers(messengers...))
+}
+
+// WithMessengers adds the messengers to Nofy, see AddMessenger.
+func WithMessengers(messengers ...Messenger) Option {
+	return func(s *Nofy) {
+		for _, m := range messengers {
+			s.AddMessenger(m)
+		}
 	}
 }
 
-func (s *Nofy) AddMessenger(m Messenger) {
-	s.messengers = append(s.messengers, m)
+// WithMaxConcurrency limits how many messengers are called at the same time.
+// Messengers are started in the order they were added, so none of them is starved.
+// Zero or less means no limit.
+func WithMaxConcurrency(n int) Option {
+	return func(s *Nofy) {
+		s.maxConcurrency = n
+	}
 }
 
-func (s *Nofy) RemoveMessenger(m Messenger) {
-	for i, msgr := range s.messengers {
-		if msgr == m {
-			s.messengers = append(s.messengers[:i], s.messengers[i+1:]...)
-			break
-		}
+// WithSequential calls the messengers one at a time, in the order they were added.
+func WithSequential() Option {
+	return WithMaxConcurrency(1)
+}
+
+// WithStopOnFailure stops starting messengers once one of them fails.
+// Messengers already in flight are completed and the remaining ones
+// are reported with ErrSkipped.
+// Combined with WithSequential, no messenger is called after the first failure.
+func WithStopOnFailure() Option {
+	return func(s *Nofy) {
+		s.stopOnFailure = true
 	}
 }
 
+// SendAll sends the configured message of every messenger.
+// Messengers are called concurrently unless limited by WithMaxConcurrency or WithSequential.
+// When at least one messenger fails, the returned error is a *SendResult.
 func (s *Nofy) SendAll(ctx context.Context) error {
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(s.messengers))
+	return s.SendAllResult(ctx).Err()
+}
+
+// SendAllResult sends the configured message of every messenger
+// and returns the outcome of every messenger.
+func (s *Nofy) SendAllResult(ctx context.Context) *SendResult {
+	return s.run(ctx, nil, func(ctx context.Context, m Messenger) error {
+		return m.Send(ctx)
+	})
+}
+
+// NotifyAll delivers the notification through every messenger.
+// Messengers are called concurrently unless limited by WithMaxConcurrency or WithSequential.
+// When at least one messenger fails, the returned error is a *SendResult.
+func (s *Nofy) NotifyAll(ctx context.Context, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	return s.NotifyAllResult(ctx, n).Err()
+}
+
+// NotifyAllResult delivers the notification through every messenger
+// and returns the outcome of every messenger.
+func (s *Nofy) NotifyAllResult(ctx context.Context, n Notification) *SendResult {
+	return s.run(ctx, nil, func(ctx context.Context, m Messenger) error {
+		return m.Notify(ctx, n)
+	})
+}
 
-	for _, m := range s.messengers {
+// NotifyAllExcept delivers the notification through every messenger
+// except the ones registered under the given names, e.g. to retry only
+// the messengers that failed a previous NotifyAll.
+// When at least one messenger fails, the returned error is a *SendResult
+// with the outcome of the messengers that were called.
+func (s *Nofy) NotifyAllExcept(ctx context.Context, n Notification, names ...string) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	return s.run(ctx, names, func(ctx context.Context, m Messenger) error {
+		return m.Notify(ctx, n)
+	}).Err()
+}
+
+// run calls send for every enabled messenger not named in except using a pool of workers
+// that picks the messengers in the order they were registered.
+// It works on a snapshot of the registrations and middlewares taken when it starts.
+func (s *Nofy) run(
+	ctx context.Context,
+	except []string,
+	send func(context.Context, Messenger) error,
+) *SendResult {
+	middlewares := s.registry.middlewareSnapshot()
+	messengers := make([]Registration, 0)
+	for _, registration := range s.registry.snapshot() {
+		if registration.Enabled && !slices.Contains(except, registration.Name) {
+			messengers = append(messengers, registration)
+		}
+	}
+	results := make([]Result, len(messengers))
+
+	workers := len(messengers)
+	if s.maxConcurrency > 0 {
+		workers = min(workers, s.maxConcurrency)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		stopped atomic.Bool
+	)
+	jobs := make(chan int)
+
+	for range workers {
 		wg.Add(1)
-		go func(m Messenger) {
+		go func() {
 			defer wg.Done()
-			defer func() {
-				if r := recover(); r != nil {
-					errChan <- fmt.Errorf("panic recovered: %v", r)
+			for i := range jobs {
+				if stopped.Load() {
+					results[i] = Result{
+						Messenger: messengers[i].Name,
+						Index:     i,
+						Err:       ErrSkipped,
+					}
+					s.logResult(ctx, results[i])
+					continue
+				}
+
+				messenger := Chain(
+					&named{Messenger: messengers[i].Messenger, name: messengers[i].Name},
+					middlewares...,
+				)
+				results[i] = deliver(ctx, i, messengers[i].Name, messenger, send)
+				s.logResult(ctx, results[i])
+				if s.stopOnFailure && !results[i].OK() {
+					stopped.Store(true)
 				}
-			}()
-			if err := m.Send(ctx); err != nil {
-				errChan <- err
 			}
-		}(m)
+		}()
 	}
 
+	for i := range messengers {
+		jobs <- i
+	}
+	close(jobs)
+
 	wg.Wait()
-	close(errChan)
 
-	return aggregateErrors(errChan)
+	return &SendResult{Results: results}
 }
 
-func aggregateErrors(errChan <-chan error) error {
-	var errors []string
-	for err := range errChan {
-		if err != nil {
-			errors = append(errors, err.Error())
-		}
+// deliver sends through a single messenger and records the outcome.
+func deliver(
+	ctx context.Context,
+	index int,
+	name string,
+	m Messenger,
+	send func(context.Context, Messenger) error,
+) Result {
+	result := Result{
+		Messenger: name,
+		Index:     index,
 	}
+	var attempts atomic.Int32
+	start := time.Now()
 
-	if len(errors) > 0 {
-		return fmt.Errorf(
-			"errors: %s",
-			strings.Join(errors, "; "),
-		)
-	}
+	result.Err = call(request.ContextWithAttemptCounter(ctx, &attempts), m, send)
+	result.Duration = time.Since(start)
+	result.Attempts = max(int(attempts.Load()), 1)
+
+	return result
+}
+
+// call calls send with the messenger, recovering from panics.
+func call(
+	ctx context.Context,
+	m Messenger,
+	send func(context.Context, Messenger) error,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered: %v", r)
+		}
+	}()
 
-	return nil
+	return send(ctx, m)
 }
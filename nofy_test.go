@@ -3,13 +3,26 @@ package nofy
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/request"
 )
 
 type MockMessenger struct {
-	sendFunc func(ctx context.Context) error
+	sendFunc   func(ctx context.Context) error
+	notifyFunc func(ctx context.Context, n Notification) error
+	name       string
+}
+
+func (m *MockMessenger) Name() string {
+	return m.name
 }
 
 func (m *MockMessenger) Send(ctx context.Context) error {
@@ -19,12 +32,66 @@ func (m *MockMessenger) Send(ctx context.Context) error {
 	return nil
 }
 
+func (m *MockMessenger) Notify(ctx context.Context, n Notification) error {
+	if m.notifyFunc != nil {
+		return m.notifyFunc(ctx, n)
+	}
+	return nil
+}
+
+// fakeClock is a manual clock for the wrappers that read the time or schedule timers.
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// AfterFunc records the function so tests can fire the timer by calling it.
+func (c *fakeClock) AfterFunc(_ time.Duration, f func()) stopper {
+	timer := &fakeTimer{f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+type fakeTimer struct {
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
 func TestNew(t *testing.T) {
 	t.Run("should create a new Nofy instance with no messengers", func(t *testing.T) {
 		nofy := New()
 
 		assert.IsNotNil(t, nofy, "Expected Nofy instance to be created")
-		assert.AreEqual(t, len(nofy.messengers), 0, "Expected messengers list to be empty")
+		assert.AreEqual(t, len(nofy.List()), 0, "Expected messengers list to be empty")
+	})
+}
+
+func TestNewWithOptions(t *testing.T) {
+	t.Run("should apply options to the Nofy instance", func(t *testing.T) {
+		nofy := New(
+			WithMessengers(&MockMessenger{}, &MockMessenger{}),
+			WithMaxConcurrency(5),
+			WithStopOnFailure(),
+		)
+
+		assert.AreEqual(t, len(nofy.List()), 2, "Expected messengers to be added")
+		assert.AreEqual(t, nofy.maxConcurrency, 5, "Expected max concurrency to be set")
+		assert.AreEqual(t, nofy.stopOnFailure, true, "Expected stop on failure to be set")
+	})
+
+	t.Run("should set max concurrency to one with WithSequential option", func(t *testing.T) {
+		nofy := New(WithSequential())
+
+		assert.AreEqual(t, nofy.maxConcurrency, 1, "Expected max concurrency to be 1")
 	})
 }
 
@@ -36,7 +103,7 @@ func TestNewWithMessengers(t *testing.T) {
 		nofy := NewWithMessengers(mockMessenger1, mockMessenger2)
 
 		assert.IsNotNil(t, nofy, "Expected Nofy instance to be created")
-		assert.AreEqual(t, len(nofy.messengers), 2, "Expected messengers list to contain 2 items")
+		assert.AreEqual(t, len(nofy.List()), 2, "Expected messengers list to contain 2 items")
 	})
 
 	t.Run(
@@ -45,7 +112,7 @@ func TestNewWithMessengers(t *testing.T) {
 			nofy := NewWithMessengers()
 
 			assert.IsNotNil(t, nofy, "Expected Nofy instance to be created")
-			assert.AreEqual(t, len(nofy.messengers), 0, "Expected messengers list to be empty")
+			assert.AreEqual(t, len(nofy.List()), 0, "Expected messengers list to be empty")
 		},
 	)
 }
@@ -59,7 +126,7 @@ func TestAddMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			s.messengers[0],
+			s.List()[0].Messenger,
 			m1,
 			"Expected messenger to be added",
 		)
@@ -75,13 +142,13 @@ func TestAddMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			s.messengers[0],
+			s.List()[0].Messenger,
 			m1,
 			"Expected first messenger to be added",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[1],
+			s.List()[1].Messenger,
 			m2,
 			"Expected second messenger to be added",
 		)
@@ -102,19 +169,19 @@ func TestRemoveMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			len(s.messengers),
+			len(s.List()),
 			2,
 			"Expected one messenger to be removed",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[0],
+			s.List()[0].Messenger,
 			m1,
 			"Expected first messenger to remain",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[1],
+			s.List()[1].Messenger,
 			m3,
 			"Expected last messenger to remain",
 		)
@@ -132,19 +199,19 @@ func TestRemoveMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			len(s.messengers),
+			len(s.List()),
 			2,
 			"Expected no messenger to be removed",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[0],
+			s.List()[0].Messenger,
 			m1,
 			"Expected first messenger to remain",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[1],
+			s.List()[1].Messenger,
 			m3,
 			"Expected last messenger to remain",
 		)
@@ -161,13 +228,13 @@ func TestRemoveMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			len(s.messengers),
+			len(s.List()),
 			1,
 			"Expected one messenger to be removed",
 		)
 		assert.AreEqual(
 			t,
-			s.messengers[0],
+			s.List()[0].Messenger,
 			m2,
 			"Expected last messenger to remain",
 		)
@@ -182,131 +249,366 @@ func TestRemoveMessenger(t *testing.T) {
 
 		assert.AreEqual(
 			t,
-			len(s.messengers),
+			len(s.List()),
 			0,
 			"Expected one messenger to be removed",
 		)
 	})
 }
 
-func TestAggregateErrors(t *testing.T) {
-	t.Run("should return nil when no errors are present", func(t *testing.T) {
-		errChan := make(chan error)
-		close(errChan)
+func TestSendAll(t *testing.T) {
+	t.Run("should return nil when messages are sent successfully", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{},
+			&MockMessenger{},
+		)
 
-		err := aggregateErrors(errChan)
+		err := s.SendAll(context.Background())
 
 		assert.IsNil(t, err, "Expected no errors")
 	})
 
-	t.Run("should return an error when one error is present", func(t *testing.T) {
-		errChan := make(chan error, 1)
-		errChan <- errors.New("single error occurred")
-		close(errChan)
-		expectedErr := errors.New("errors: single error occurred")
+	t.Run("should return an error when one messenger fails", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{},
+			&MockMessenger{
+				name: "mock",
+				sendFunc: func(ctx context.Context) error {
+					return errors.New("failed to send message")
+				},
+			},
+		)
+		expectedErr := errors.New("errors: mock: failed to send message")
 
-		err := aggregateErrors(errChan)
+		err := s.SendAll(context.Background())
 
 		assert.AreEqualErrs(
 			t,
 			err,
 			expectedErr,
-			"Expected single error",
+			"Expected one error",
 		)
 	})
 
-	t.Run("should return an error when multiple errors are present", func(t *testing.T) {
-		errChan := make(chan error, 2)
-		errChan <- errors.New("first error")
-		errChan <- errors.New("second error")
-		close(errChan)
-		expectedErr := errors.New("errors: first error; second error")
+	t.Run("should return an error error when all messages fail", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{
+				sendFunc: func(_ context.Context) error {
+					return errors.New("first message failed")
+				},
+			},
+			&MockMessenger{
+				sendFunc: func(_ context.Context) error {
+					return errors.New("second message failed")
+				},
+			},
+		)
 
-		err := aggregateErrors(errChan)
+		err := s.SendAll(context.Background())
 
-		assert.AreEqualErrs(
-			t,
-			err,
-			expectedErr,
-			"Expected multiple errors",
+		assert.IsNotNil(t, err, "Expected errors")
+	})
+
+	t.Run("should handle panic gracefully and return error", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{
+				name: "mock",
+				sendFunc: func(_ context.Context) error {
+					panic("unexpected panic")
+				},
+			},
+			&MockMessenger{},
 		)
+		expectedErr := errors.New("errors: mock: panic recovered: unexpected panic")
+
+		err := s.SendAll(context.Background())
+
+		assert.IsNotNil(t, err, "Expected an error due to panic")
+		assert.AreEqualErrs(t, err, expectedErr, "Expected panic to be recovered")
+	})
+
+	t.Run("should keep the original errors of failed messengers", func(t *testing.T) {
+		errRateLimited := errors.New("rate limited")
+		s := NewWithMessengers(
+			&MockMessenger{name: "first"},
+			&MockMessenger{
+				name: "second",
+				sendFunc: func(_ context.Context) error {
+					return fmt.Errorf("error sending message: %w", errRateLimited)
+				},
+			},
+		)
+
+		err := s.SendAll(context.Background())
+
+		var messengerErr *MessengerError
+		assert.AreEqual(t, errors.Is(err, errRateLimited), true, "Expected errors.Is to match")
+		assert.AreEqual(t, errors.As(err, &messengerErr), true, "Expected errors.As to match")
+		assert.AreEqual(t, messengerErr.Messenger, "second")
+		assert.AreEqual(t, messengerErr.Index, 1)
 	})
 }
 
-func TestSendAll(t *testing.T) {
-	t.Run("should return nil when messages are sent successfully", func(t *testing.T) {
-		s := &Nofy{
-			messengers: []Messenger{
-				&MockMessenger{},
-				&MockMessenger{},
+func TestSendAllResult(t *testing.T) {
+	t.Run("should record the outcome of every messenger", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{name: "first"},
+			&MockMessenger{
+				name: "second",
+				sendFunc: func(_ context.Context) error {
+					return errors.New("failed to send message")
+				},
 			},
+		)
+
+		result := s.SendAllResult(context.Background())
+
+		assert.AreEqual(t, len(result.Results), 2)
+		assert.AreEqual(t, result.Results[0].Messenger, "first")
+		assert.AreEqual(t, result.Results[0].OK(), true)
+		assert.AreEqual(t, result.Results[0].Attempts, 1)
+		assert.AreEqual(t, result.Results[1].Messenger, "second")
+		assert.AreEqual(t, result.Results[1].OK(), false)
+		assert.AreEqual(t, len(result.Succeeded()), 1)
+		assert.AreEqual(t, len(result.Failed()), 1)
+		assert.IsNotNil(t, result.Err())
+	})
+
+	t.Run("should record the attempts made by the messenger", func(t *testing.T) {
+		s := NewWithMessengers(
+			&MockMessenger{
+				sendFunc: func(ctx context.Context) error {
+					server := httptest.NewServer(http.HandlerFunc(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusBadGateway)
+						},
+					))
+					defer server.Close()
+
+					_, _, err := request.NewRequester(
+						request.WithRetry(request.RetryPolicy{MaxAttempts: 3}),
+					).Do(
+						ctx,
+						request.WithMethod(http.MethodGet),
+						request.WithURL(server.URL),
+					)
+					return err
+				},
+			},
+		)
+
+		result := s.SendAllResult(context.Background())
+
+		assert.AreEqual(t, result.Results[0].Attempts, 3)
+	})
+
+	t.Run("should return no error when every messenger succeeds", func(t *testing.T) {
+		s := NewWithMessengers(&MockMessenger{}, &MockMessenger{})
+
+		result := s.SendAllResult(context.Background())
+
+		assert.AreEqual(t, len(result.Succeeded()), 2)
+		assert.IsNil(t, result.Err())
+	})
+}
+
+func TestNotifyAll(t *testing.T) {
+	t.Run("should deliver the notification to every messenger", func(t *testing.T) {
+		received := make(chan Notification, 2)
+		notifyFunc := func(_ context.Context, n Notification) error {
+			received <- n
+			return nil
 		}
+		s := NewWithMessengers(
+			&MockMessenger{notifyFunc: notifyFunc},
+			&MockMessenger{notifyFunc: notifyFunc},
+		)
+		notification := Notification{Title: "Deploy finished", Severity: SeverityInfo}
 
-		err := s.SendAll(context.Background())
+		err := s.NotifyAll(context.Background(), notification)
+		close(received)
 
 		assert.IsNil(t, err, "Expected no errors")
+		for n := range received {
+			assert.AreEqual(t, n, notification, "Expected notification to be delivered")
+		}
 	})
 
 	t.Run("should return an error when one messenger fails", func(t *testing.T) {
-		s := &Nofy{
-			messengers: []Messenger{
-				&MockMessenger{},
-				&MockMessenger{
-					sendFunc: func(ctx context.Context) error {
-						return errors.New("failed to send message")
-					},
+		s := NewWithMessengers(
+			&MockMessenger{},
+			&MockMessenger{
+				name: "mock",
+				notifyFunc: func(_ context.Context, _ Notification) error {
+					return errors.New("failed to notify")
 				},
 			},
-		}
-		expectedErr := errors.New("errors: failed to send message")
+		)
+		expectedErr := errors.New("errors: mock: failed to notify")
 
-		err := s.SendAll(context.Background())
+		err := s.NotifyAll(context.Background(), Notification{Title: "Disk full"})
 
-		assert.AreEqualErrs(
-			t,
-			err,
-			expectedErr,
-			"Expected one error",
-		)
+		assert.AreEqualErrs(t, err, expectedErr, "Expected one error")
 	})
 
-	t.Run("should return an error error when all messages fail", func(t *testing.T) {
-		s := &Nofy{
-			messengers: []Messenger{
-				&MockMessenger{
-					sendFunc: func(_ context.Context) error {
-						return errors.New("first message failed")
-					},
-				},
-				&MockMessenger{
-					sendFunc: func(_ context.Context) error {
-						return errors.New("second message failed")
-					},
+	t.Run("should return an error when the notification is empty", func(t *testing.T) {
+		called := false
+		s := NewWithMessengers(
+			&MockMessenger{
+				notifyFunc: func(_ context.Context, _ Notification) error {
+					called = true
+					return nil
 				},
 			},
+		)
+
+		err := s.NotifyAll(context.Background(), Notification{})
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+		assert.AreEqual(t, called, false, "Expected messenger not to be called")
+	})
+}
+
+func TestNotifyAllExcept(t *testing.T) {
+	t.Run("should skip the messengers with the given names", func(t *testing.T) {
+		var called []string
+		var mu sync.Mutex
+		notifyFunc := func(name string) func(context.Context, Notification) error {
+			return func(_ context.Context, _ Notification) error {
+				mu.Lock()
+				defer mu.Unlock()
+				called = append(called, name)
+				return errors.New("failed to notify")
+			}
+		}
+		s := New()
+		_ = s.Register("slack", &MockMessenger{notifyFunc: notifyFunc("slack")})
+		_ = s.Register("resend", &MockMessenger{notifyFunc: notifyFunc("resend")})
+
+		err := s.NotifyAllExcept(context.Background(), Notification{Title: "Disk full"}, "slack")
+
+		var result *SendResult
+		assert.AreEqual(t, errors.As(err, &result), true)
+		assert.AreEqual(t, len(result.Results), 1)
+		assert.AreEqual(t, result.Results[0].Messenger, "resend")
+		assert.AreEqual(t, called, []string{"resend"})
+	})
+
+	t.Run("should return an error when the notification is empty", func(t *testing.T) {
+		s := NewWithMessengers(&MockMessenger{})
+
+		err := s.NotifyAllExcept(context.Background(), Notification{})
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+	})
+}
+
+func TestSendAllConcurrency(t *testing.T) {
+	t.Run("should not exceed the max concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight, calls atomic.Int32
+		send := func(_ context.Context) error {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxInFlight.Load()
+				if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			calls.Add(1)
+			time.Sleep(2 * time.Millisecond)
+			return nil
+		}
+		s := New(WithMaxConcurrency(3))
+		for range 30 {
+			s.AddMessenger(&MockMessenger{sendFunc: send})
 		}
 
 		err := s.SendAll(context.Background())
 
-		assert.IsNotNil(t, err, "Expected errors")
+		assert.IsNil(t, err)
+		assert.AreEqual(t, calls.Load(), int32(30), "Expected every messenger to be called")
+		if maxInFlight.Load() > 3 {
+			t.Errorf("expected at most 3 messengers in flight, got %d", maxInFlight.Load())
+		}
 	})
 
-	t.Run("should handle panic gracefully and return error", func(t *testing.T) {
-		s := &Nofy{
-			messengers: []Messenger{
-				&MockMessenger{
-					sendFunc: func(_ context.Context) error {
-						panic("unexpected panic")
-					},
+	t.Run("should call messengers in order in sequential mode", func(t *testing.T) {
+		var mu sync.Mutex
+		order := make([]int, 0)
+		s := New(WithSequential())
+		for i := range 10 {
+			s.AddMessenger(&MockMessenger{
+				sendFunc: func(_ context.Context) error {
+					mu.Lock()
+					defer mu.Unlock()
+					order = append(order, i)
+					return nil
 				},
-				&MockMessenger{},
-			},
+			})
 		}
-		expectedErr := errors.New("errors: panic recovered: unexpected panic")
 
 		err := s.SendAll(context.Background())
 
-		assert.IsNotNil(t, err, "Expected an error due to panic")
-		assert.AreEqualErrs(t, err, expectedErr, "Expected panic to be recovered")
+		assert.IsNil(t, err)
+		assert.AreEqual(t, order, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	})
+
+	t.Run("should skip remaining messengers after the first failure", func(t *testing.T) {
+		var calls atomic.Int32
+		s := New(
+			WithSequential(),
+			WithStopOnFailure(),
+			WithMessengers(
+				&MockMessenger{name: "first", sendFunc: func(_ context.Context) error {
+					calls.Add(1)
+					return nil
+				}},
+				&MockMessenger{name: "second", sendFunc: func(_ context.Context) error {
+					calls.Add(1)
+					return errors.New("failed to send message")
+				}},
+				&MockMessenger{name: "third", sendFunc: func(_ context.Context) error {
+					calls.Add(1)
+					return nil
+				}},
+			),
+		)
+
+		result := s.SendAllResult(context.Background())
+
+		assert.AreEqual(t, calls.Load(), int32(2), "Expected third messenger not to be called")
+		assert.AreEqual(t, result.Results[0].OK(), true)
+		assert.AreEqualErrs(t, result.Results[1].Err, errors.New("failed to send message"))
+		assert.AreEqual(t, errors.Is(result.Results[2].Err, ErrSkipped), true)
+		assert.AreEqual(t, result.Results[2].Messenger, "third")
+		assert.AreEqual(t, errors.Is(result.Err(), ErrSkipped), true)
+	})
+
+	t.Run("should complete messengers in flight after the first failure", func(t *testing.T) {
+		release := make(chan struct{})
+		var completed atomic.Bool
+		s := New(
+			WithMaxConcurrency(2),
+			WithStopOnFailure(),
+			WithMessengers(
+				&MockMessenger{sendFunc: func(_ context.Context) error {
+					<-release
+					completed.Store(true)
+					return nil
+				}},
+				&MockMessenger{sendFunc: func(_ context.Context) error {
+					time.AfterFunc(50*time.Millisecond, func() { close(release) })
+					return errors.New("failed to send message")
+				}},
+				&MockMessenger{},
+			),
+		)
+
+		result := s.SendAllResult(context.Background())
+
+		assert.AreEqual(t, completed.Load(), true, "Expected in flight messenger to complete")
+		assert.AreEqual(t, result.Results[0].OK(), true)
+		assert.AreEqual(t, errors.Is(result.Results[2].Err, ErrSkipped), true)
 	})
 }
@@ -0,0 +1,80 @@
+package nofy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity indicates how urgent a notification is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// String returns the lower case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Notification is a channel-agnostic message.
+// Each messenger renders it into its native format (Slack blocks, email HTML, ...).
+// Title is a short summary of the notification.
+// Body is the main content of the notification.
+// Severity indicates how urgent the notification is.
+// Fields are key/value pairs displayed alongside the body.
+// Links are references to external resources (dashboards, runbooks, ...).
+// Attachments are files delivered with the notification when the messenger supports them.
+// Metadata is free-form data that is not rendered but can be used to route or group notifications.
+type Notification struct {
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Title       string            `json:"title"`
+	Body        string            `json:"body"`
+	Fields      []Field           `json:"fields,omitempty"`
+	Links       []Link            `json:"links,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	Severity    Severity          `json:"severity"`
+}
+
+// Field is a key/value pair displayed alongside the notification body.
+// Inline hints that the field is short enough to be displayed side by side with others.
+type Field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// Link is a reference to an external resource.
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// Attachment is a file delivered with the notification.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     []byte `json:"content"`
+}
+
+// Validate checks that the notification has content to deliver.
+func (n Notification) Validate() error {
+	if strings.TrimSpace(n.Title) == "" && strings.TrimSpace(n.Body) == "" {
+		return fmt.Errorf("missing title or body")
+	}
+	return nil
+}
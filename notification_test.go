@@ -0,0 +1,41 @@
+package nofy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestSeverityString(t *testing.T) {
+	t.Run("should return the name of known severities", func(t *testing.T) {
+		assert.AreEqual(t, SeverityInfo.String(), "info")
+		assert.AreEqual(t, SeverityWarning.String(), "warning")
+		assert.AreEqual(t, SeverityError.String(), "error")
+		assert.AreEqual(t, SeverityCritical.String(), "critical")
+	})
+
+	t.Run("should return the value of unknown severities", func(t *testing.T) {
+		assert.AreEqual(t, Severity(42).String(), "severity(42)")
+	})
+}
+
+func TestNotificationValidate(t *testing.T) {
+	t.Run("should pass validation when title is present", func(t *testing.T) {
+		err := Notification{Title: "Deploy finished"}.Validate()
+
+		assert.IsNil(t, err)
+	})
+
+	t.Run("should pass validation when body is present", func(t *testing.T) {
+		err := Notification{Body: "Deploy finished"}.Validate()
+
+		assert.IsNil(t, err)
+	})
+
+	t.Run("should return error when title and body are blank", func(t *testing.T) {
+		err := Notification{Title: " ", Body: "\n"}.Validate()
+
+		assert.AreEqualErrs(t, err, errors.New("missing title or body"))
+	})
+}
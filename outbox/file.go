@@ -0,0 +1,306 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fileExtension = ".json"
+	// doneDir, deadDir and corruptDir are the subdirectories of the delivered,
+	// dead-lettered and corrupt entries, kept out of the directory scanned for due entries.
+	doneDir    = "done"
+	deadDir    = "dead"
+	corruptDir = "corrupt"
+)
+
+// errCorruptEntry is returned when an entry file cannot be decoded.
+var errCorruptEntry = errors.New("corrupt entry")
+
+// FileStore keeps every entry in its own JSON file in a directory.
+// Files are written to a temporary file, synced and renamed,
+// so an entry is either fully saved or not saved at all when the process crashes.
+// Pending entries are kept at the root of the directory and the others in the done
+// and dead subdirectories, so looking for due entries only reads the pending ones.
+type FileStore struct {
+	logger *slog.Logger
+	dir    string
+	mu     sync.Mutex
+}
+
+type FileStoreOption func(*FileStore)
+
+// NewFileStore creates a store in the directory, creating it if needed.
+func NewFileStore(dir string, options ...FileStoreOption) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("missing directory")
+	}
+
+	for _, sub := range []string{doneDir, deadDir, corruptDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("error creating directory: %w", err)
+		}
+	}
+
+	store := &FileStore{dir: dir}
+	for _, opt := range options {
+		opt(store)
+	}
+
+	return store, nil
+}
+
+// WithFileLogger sets the logger used to report the entry files that cannot be read.
+func WithFileLogger(logger *slog.Logger) FileStoreOption {
+	return func(s *FileStore) {
+		s.logger = logger
+	}
+}
+
+// Save inserts or replaces the entry, moving it to the directory of its status.
+func (s *FileStore) Save(_ context.Context, entry Entry) error {
+	if err := validateID(entry.ID); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshalling entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := entryPath(s.statusDir(entry.Status), entry.ID)
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+
+	for _, dir := range s.dirs() {
+		if other := entryPath(dir, entry.ID); other != path {
+			if err := remove(other); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the entry with the given ID or ErrNotFound.
+func (s *FileStore) Get(_ context.Context, id string) (Entry, error) {
+	if err := validateID(id); err != nil {
+		return Entry{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, dir := range s.dirs() {
+		entry, err := readEntry(entryPath(dir, id))
+		if !errors.Is(err, ErrNotFound) {
+			return entry, err
+		}
+	}
+	return Entry{}, ErrNotFound
+}
+
+// Delete removes the entry with the given ID.
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, dir := range s.dirs() {
+		if err := remove(entryPath(dir, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Due returns up to limit pending entries due at the given time, oldest first.
+func (s *FileStore) Due(ctx context.Context, now time.Time, limit int) ([]Entry, error) {
+	return s.scan(ctx, s.dir, limit, func(entry Entry) bool {
+		return isDue(entry, now)
+	})
+}
+
+// DeadLetters returns the entries that exhausted their attempts, oldest first.
+func (s *FileStore) DeadLetters(ctx context.Context) ([]Entry, error) {
+	return s.scan(ctx, filepath.Join(s.dir, deadDir), 0, isDead)
+}
+
+// scan returns up to limit entries of the directory matching keep, oldest first.
+// Corrupt files are moved to the corrupt subdirectory and unreadable files are skipped,
+// both are logged, so that a single bad file does not stop the delivery of the others.
+func (s *FileStore) scan(
+	ctx context.Context,
+	dir string,
+	limit int,
+	keep func(Entry) bool,
+) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %w", err)
+	}
+
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == fileExtension {
+			ids = append(ids, strings.TrimSuffix(file.Name(), fileExtension))
+		}
+	}
+	sort.Strings(ids)
+
+	entries := make([]Entry, 0)
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+
+		path := entryPath(dir, id)
+		entry, err := readEntry(path)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case errors.Is(err, errCorruptEntry):
+			s.quarantine(ctx, path, err)
+		case err != nil:
+			s.log(ctx, "skipping unreadable entry", slog.String("file", path), slog.Any("error", err))
+		case keep(entry):
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// quarantine moves a corrupt entry file to the corrupt subdirectory.
+// It must be called with the lock held.
+func (s *FileStore) quarantine(ctx context.Context, path string, err error) {
+	target := filepath.Join(s.dir, corruptDir, filepath.Base(path))
+	if renameErr := os.Rename(path, target); renameErr != nil {
+		s.log(ctx, "skipping corrupt entry", slog.String("file", path), slog.Any("error", err))
+		return
+	}
+	s.log(ctx, "quarantined corrupt entry", slog.String("file", target), slog.Any("error", err))
+}
+
+func (s *FileStore) log(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if s.logger == nil {
+		return
+	}
+	s.logger.LogAttrs(ctx, slog.LevelWarn, msg, attrs...)
+}
+
+// dirs returns the directories an entry can be in.
+func (s *FileStore) dirs() []string {
+	return []string{s.dir, filepath.Join(s.dir, doneDir), filepath.Join(s.dir, deadDir)}
+}
+
+// statusDir returns the directory of the entries with the status.
+func (s *FileStore) statusDir(status Status) string {
+	switch status {
+	case StatusDone:
+		return filepath.Join(s.dir, doneDir)
+	case StatusDead:
+		return filepath.Join(s.dir, deadDir)
+	default:
+		return s.dir
+	}
+}
+
+// validateID rejects IDs that would escape the directory.
+func validateID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return fmt.Errorf("invalid id: %q", id)
+	}
+	return nil
+}
+
+// entryPath returns the file of the entry in the directory.
+func entryPath(dir, id string) string {
+	return filepath.Join(dir, id+fileExtension)
+}
+
+// remove removes the file, ignoring files that do not exist.
+func remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error deleting entry: %w", err)
+	}
+	return nil
+}
+
+func readEntry(path string) (Entry, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from a validated id
+	if errors.Is(err, fs.ErrNotExist) {
+		return Entry{}, ErrNotFound
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("error reading entry: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("%w %s: %w", errCorruptEntry, filepath.Base(path), err)
+	}
+	return entry, nil
+}
+
+// writeFile atomically replaces the file with data.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing entry: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error syncing entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error saving entry: %w", err)
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir makes the rename durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir) // #nosec G304 -- dir is the directory of the store
+	if err != nil {
+		return fmt.Errorf("error syncing directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return fmt.Errorf("error syncing directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		store, err := NewFileStore(t.TempDir())
+		assert.IsNil(t, err)
+		return store
+	})
+
+	t.Run("should keep entries across stores of the same directory", func(t *testing.T) {
+		dir := t.TempDir()
+		first, _ := NewFileStore(dir)
+		_ = first.Save(context.Background(), newTestEntry("1", StatusPending, testTime))
+
+		second, _ := NewFileStore(dir)
+		entries, err := second.Due(context.Background(), testTime, 10)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"1"})
+	})
+
+	t.Run("should create the directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "outbox")
+
+		_, err := NewFileStore(dir)
+		_, statErr := os.Stat(dir)
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, statErr)
+	})
+
+	t.Run("should return an error when the directory is missing", func(t *testing.T) {
+		_, err := NewFileStore("")
+
+		assert.AreEqual(t, err.Error(), "missing directory")
+	})
+
+	t.Run("should reject ids escaping the directory", func(t *testing.T) {
+		store, _ := NewFileStore(t.TempDir())
+
+		err := store.Save(context.Background(), newTestEntry("../1", StatusPending, testTime))
+
+		assert.AreEqual(t, err.Error(), `invalid id: "../1"`)
+	})
+
+	t.Run("should ignore files that are not entries", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir)
+		_ = os.WriteFile(filepath.Join(dir, "1.json.123.tmp"), []byte("{"), 0o600)
+
+		entries, err := store.Due(context.Background(), testTime, 10)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, len(entries), 0)
+	})
+
+	t.Run("should move delivered and dead entries out of the pending directory", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir)
+		entry := newTestEntry("1", StatusPending, testTime)
+		_ = store.Save(context.Background(), entry)
+
+		entry.Status = StatusDead
+		err := store.Save(context.Background(), entry)
+		_, pendingErr := os.Stat(filepath.Join(dir, "1.json"))
+		_, deadErr := os.Stat(filepath.Join(dir, "dead", "1.json"))
+		got, _ := store.Get(context.Background(), "1")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, errors.Is(pendingErr, fs.ErrNotExist), true)
+		assert.IsNil(t, deadErr)
+		assert.AreEqual(t, got.Status, StatusDead)
+	})
+
+	t.Run("should quarantine corrupt entries and return the others", func(t *testing.T) {
+		dir := t.TempDir()
+		var logs bytes.Buffer
+		store, _ := NewFileStore(dir, WithFileLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+		_ = os.WriteFile(filepath.Join(dir, "1.json"), []byte("{"), 0o600)
+		_ = store.Save(context.Background(), newTestEntry("2", StatusPending, testTime))
+
+		entries, err := store.Due(context.Background(), testTime, 10)
+		_, corruptErr := os.Stat(filepath.Join(dir, "corrupt", "1.json"))
+		again, _ := store.Due(context.Background(), testTime, 10)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"2"})
+		assert.IsNil(t, corruptErr)
+		assert.AreEqual(t, ids(again), []string{"2"})
+		assert.AreEqual(t, strings.Contains(logs.String(), "quarantined corrupt entry"), true)
+	})
+
+	t.Run("should skip unreadable entries", func(t *testing.T) {
+		dir := t.TempDir()
+		var logs bytes.Buffer
+		store, _ := NewFileStore(dir, WithFileLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+		_ = os.Symlink(t.TempDir(), filepath.Join(dir, "1.json"))
+		_ = store.Save(context.Background(), newTestEntry("2", StatusPending, testTime))
+
+		entries, err := store.Due(context.Background(), testTime, 10)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"2"})
+		assert.AreEqual(t, strings.Contains(logs.String(), "skipping unreadable entry"), true)
+	})
+}
@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps entries in memory.
+// Entries do not survive restarts, it is meant for tests and for processes
+// that only need the retries and dead letters of the outbox.
+type MemoryStore struct {
+	entries map[string]Entry
+	mu      sync.Mutex
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]Entry),
+	}
+}
+
+// Save inserts or replaces the entry.
+func (s *MemoryStore) Save(_ context.Context, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+// Get returns the entry with the given ID or ErrNotFound.
+func (s *MemoryStore) Get(_ context.Context, id string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return Entry{}, ErrNotFound
+	}
+	return entry, nil
+}
+
+// Delete removes the entry with the given ID.
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+	return nil
+}
+
+// Due returns up to limit pending entries due at the given time, oldest first.
+func (s *MemoryStore) Due(_ context.Context, now time.Time, limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := filter(s.all(), func(entry Entry) bool {
+		return isDue(entry, now)
+	})
+	return head(entries, limit), nil
+}
+
+// DeadLetters returns the entries that exhausted their attempts, oldest first.
+func (s *MemoryStore) DeadLetters(_ context.Context) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return filter(s.all(), isDead), nil
+}
+
+func (s *MemoryStore) all() []Entry {
+	entries := make([]Entry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func isDue(entry Entry, now time.Time) bool {
+	return entry.Status == StatusPending && !entry.NextAttemptAt.After(now)
+}
+
+func isDead(entry Entry) bool {
+	return entry.Status == StatusDead
+}
+
+// filter returns the entries matching keep sorted by ID, so oldest first.
+func filter(entries []Entry, keep func(Entry) bool) []Entry {
+	kept := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if keep(entry) {
+			kept = append(kept, entry)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].ID < kept[j].ID
+	})
+	return kept
+}
+
+// head returns the first limit entries, or all of them when limit is not positive.
+func head(entries []Entry, limit int) []Entry {
+	if limit > 0 && len(entries) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
@@ -0,0 +1,9 @@
+package outbox
+
+import "testing"
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(_ *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
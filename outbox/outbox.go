@@ -0,0 +1,353 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+const (
+	defaultMaxAttempts  = 5
+	defaultBaseDelay    = time.Second
+	defaultMaxDelay     = 5 * time.Minute
+	defaultPollInterval = time.Second
+	minPollInterval     = time.Millisecond
+	defaultBatchSize    = 100
+)
+
+// ErrNotFound is returned by stores when an entry does not exist.
+var ErrNotFound = errors.New("entry not found")
+
+// Status is the delivery status of an entry.
+type Status string
+
+const (
+	// StatusPending entries are waiting to be delivered.
+	StatusPending Status = "pending"
+	// StatusDone entries were delivered and kept with WithKeepDelivered.
+	StatusDone Status = "done"
+	// StatusDead entries exhausted their attempts and will not be delivered.
+	StatusDead Status = "dead"
+)
+
+// Entry is a notification stored in the outbox.
+// Attempts is the number of failed deliveries and LastError the error of the last one.
+// NextAttemptAt is when the entry is due for delivery.
+// Delivered lists the messengers that already delivered the notification
+// when a delivery through several messengers partially failed.
+type Entry struct {
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	ID            string            `json:"id"`
+	Status        Status            `json:"status"`
+	LastError     string            `json:"last_error,omitempty"`
+	Delivered     []string          `json:"delivered,omitempty"`
+	Notification  nofy.Notification `json:"notification"`
+	Attempts      int               `json:"attempts"`
+}
+
+// Store persists the entries of an outbox.
+// Save must be durable when it returns, so that enqueued notifications
+// survive process restarts.
+type Store interface {
+	// Save inserts or replaces the entry.
+	Save(ctx context.Context, entry Entry) error
+	// Get returns the entry with the given ID or ErrNotFound.
+	Get(ctx context.Context, id string) (Entry, error)
+	// Delete removes the entry with the given ID, e.g. to purge delivered entries.
+	Delete(ctx context.Context, id string) error
+	// Due returns up to limit pending entries due at the given time, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]Entry, error)
+	// DeadLetters returns the entries that exhausted their attempts, oldest first.
+	DeadLetters(ctx context.Context) ([]Entry, error)
+}
+
+// Notifier delivers notifications, e.g. a nofy.Messenger or a nofy.Fallback.
+type Notifier interface {
+	Notify(ctx context.Context, n nofy.Notification) error
+}
+
+// NotifierFunc adapts a function to a Notifier.
+type NotifierFunc func(ctx context.Context, n nofy.Notification) error
+
+// Notify calls f(ctx, n).
+func (f NotifierFunc) Notify(ctx context.Context, n nofy.Notification) error {
+	return f(ctx, n)
+}
+
+// Messengers adapts n to a Notifier that delivers through every messenger of n.
+// When some messengers fail, the retries of the entry only call the messengers
+// that have not delivered it yet, so the others do not receive it twice.
+func Messengers(n *nofy.Nofy) Notifier {
+	return messengers{Nofy: n}
+}
+
+type messengers struct {
+	*nofy.Nofy
+}
+
+func (m messengers) Notify(ctx context.Context, n nofy.Notification) error {
+	return m.NotifyAll(ctx, n)
+}
+
+// partialNotifier is implemented by notifiers that can skip the messengers
+// that already delivered an entry.
+type partialNotifier interface {
+	NotifyAllExcept(ctx context.Context, n nofy.Notification, names ...string) error
+}
+
+// Outbox durably enqueues notifications and delivers them in the background.
+// Delivery is at least once: a notification whose delivery succeeded may be
+// delivered again if the process stops before it is marked done, and
+// notifiers other than Messengers deliver it again on every retry.
+type Outbox struct {
+	store        Store
+	notifier     Notifier
+	logger       *slog.Logger
+	now          func() time.Time
+	wakeup       chan struct{}
+	maxAttempts  int
+	baseDelay    time.Duration
+	maxDelay     time.Duration
+	pollInterval time.Duration
+	batchSize    int
+	keepDone     bool
+}
+
+type Option func(*Outbox)
+
+// New creates an outbox that stores entries in store and delivers them through notifier.
+func New(store Store, notifier Notifier, options ...Option) *Outbox {
+	outbox := &Outbox{
+		store:        store,
+		notifier:     notifier,
+		now:          time.Now,
+		wakeup:       make(chan struct{}, 1),
+		maxAttempts:  defaultMaxAttempts,
+		baseDelay:    defaultBaseDelay,
+		maxDelay:     defaultMaxDelay,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+	}
+
+	for _, opt := range options {
+		opt(outbox)
+	}
+
+	return outbox
+}
+
+// WithMaxAttempts sets how many deliveries are attempted before an entry is dead-lettered.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *Outbox) {
+		o.maxAttempts = max(attempts, 1)
+	}
+}
+
+// WithBackoff sets the delay before the first retry, doubled on every retry up to maxDelay.
+func WithBackoff(baseDelay, maxDelay time.Duration) Option {
+	return func(o *Outbox) {
+		o.baseDelay = baseDelay
+		o.maxDelay = maxDelay
+	}
+}
+
+// WithPollInterval sets how often the dispatcher looks for due entries, at least every millisecond.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *Outbox) {
+		o.pollInterval = max(interval, minPollInterval)
+	}
+}
+
+// WithBatchSize sets how many entries the dispatcher delivers per poll.
+func WithBatchSize(size int) Option {
+	return func(o *Outbox) {
+		o.batchSize = max(size, 1)
+	}
+}
+
+// WithKeepDelivered keeps delivered entries in the store with StatusDone,
+// e.g. for auditing. By default they are deleted once delivered,
+// so the store only grows with the entries left to deliver and the dead letters.
+func WithKeepDelivered() Option {
+	return func(o *Outbox) {
+		o.keepDone = true
+	}
+}
+
+// WithLogger sets the logger used to report deliveries.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Outbox) {
+		o.logger = logger
+	}
+}
+
+// Enqueue durably stores the notification for delivery and returns the ID of its entry.
+func (o *Outbox) Enqueue(ctx context.Context, n nofy.Notification) (string, error) {
+	if err := n.Validate(); err != nil {
+		return "", err
+	}
+
+	now := o.now()
+	id, err := newID(now)
+	if err != nil {
+		return "", err
+	}
+
+	entry := Entry{
+		ID:            id,
+		Notification:  n,
+		Status:        StatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if err := o.store.Save(ctx, entry); err != nil {
+		return "", fmt.Errorf("error saving entry: %w", err)
+	}
+
+	select {
+	case o.wakeup <- struct{}{}:
+	default:
+	}
+
+	return id, nil
+}
+
+// Run delivers due entries until the context is done.
+// Entries left pending by a previous process are delivered as well.
+func (o *Outbox) Run(ctx context.Context) error {
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := o.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			o.log(ctx, slog.LevelError, "error dispatching entries", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-o.wakeup:
+		}
+	}
+}
+
+// Dispatch delivers the entries that are due and returns how many were delivered.
+func (o *Outbox) Dispatch(ctx context.Context) (int, error) {
+	entries, err := o.store.Due(ctx, o.now(), o.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error loading due entries: %w", err)
+	}
+
+	delivered := 0
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		ok, err := o.deliver(ctx, entry)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver delivers a single entry and records the outcome in the store.
+func (o *Outbox) deliver(ctx context.Context, entry Entry) (bool, error) {
+	deliveryErr := o.notify(ctx, entry)
+	now := o.now()
+	entry.UpdatedAt = now
+
+	var result *nofy.SendResult
+	if errors.As(deliveryErr, &result) {
+		for _, succeeded := range result.Succeeded() {
+			entry.Delivered = append(entry.Delivered, succeeded.Messenger)
+		}
+	}
+
+	switch {
+	case deliveryErr == nil:
+		entry.Status = StatusDone
+		entry.LastError = ""
+		o.log(ctx, slog.LevelInfo, "entry delivered", slog.String("id", entry.ID))
+	case entry.Attempts+1 >= o.maxAttempts:
+		entry.Attempts++
+		entry.Status = StatusDead
+		entry.LastError = deliveryErr.Error()
+		o.log(ctx, slog.LevelError, "entry dead-lettered",
+			slog.String("id", entry.ID),
+			slog.Int("attempts", entry.Attempts),
+			slog.Any("error", deliveryErr),
+		)
+	default:
+		entry.Attempts++
+		entry.LastError = deliveryErr.Error()
+		entry.NextAttemptAt = now.Add(o.backoff(entry.Attempts))
+		o.log(ctx, slog.LevelWarn, "entry delivery failed",
+			slog.String("id", entry.ID),
+			slog.Int("attempts", entry.Attempts),
+			slog.Time("next_attempt_at", entry.NextAttemptAt),
+			slog.Any("error", deliveryErr),
+		)
+	}
+
+	// The outcome is recorded even when the context is done,
+	// so a delivered entry is not delivered again.
+	if entry.Status == StatusDone && !o.keepDone {
+		if err := o.store.Delete(context.WithoutCancel(ctx), entry.ID); err != nil {
+			return false, fmt.Errorf("error deleting entry: %w", err)
+		}
+	} else if err := o.store.Save(context.WithoutCancel(ctx), entry); err != nil {
+		return false, fmt.Errorf("error saving entry: %w", err)
+	}
+
+	return deliveryErr == nil, nil
+}
+
+// notify delivers the notification of the entry,
+// skipping the messengers that already delivered it when the notifier supports it.
+func (o *Outbox) notify(ctx context.Context, entry Entry) error {
+	if notifier, ok := o.notifier.(partialNotifier); ok && len(entry.Delivered) > 0 {
+		return notifier.NotifyAllExcept(ctx, entry.Notification, entry.Delivered...)
+	}
+	return o.notifier.Notify(ctx, entry.Notification)
+}
+
+// backoff returns the delay before the retry following the given number of attempts.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	delay := o.baseDelay
+	for i := 1; i < attempts && delay < o.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, o.maxDelay)
+}
+
+func (o *Outbox) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if o.logger == nil {
+		return
+	}
+	o.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// newID returns a unique ID that sorts by creation time.
+func newID(now time.Time) (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("error generating id: %w", err)
+	}
+	return fmt.Sprintf("%020d-%s", now.UnixNano(), hex.EncodeToString(random)), nil
+}
@@ -0,0 +1,276 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+var notification = nofy.Notification{Title: "Deploy failed", Body: "api v1.2.3"}
+
+// countingMessenger counts its notifications and fails them while fail is set.
+type countingMessenger struct {
+	calls int
+	fail  bool
+}
+
+func (m *countingMessenger) Send(_ context.Context) error {
+	return nil
+}
+
+func (m *countingMessenger) Notify(_ context.Context, _ nofy.Notification) error {
+	m.calls++
+	if m.fail {
+		return errors.New("service unavailable")
+	}
+	return nil
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Run("should save a pending entry due now", func(t *testing.T) {
+		store := NewMemoryStore()
+		outbox := New(store, NotifierFunc(nil))
+		outbox.now = func() time.Time { return testTime }
+
+		id, err := outbox.Enqueue(context.Background(), notification)
+		entry, getErr := store.Get(context.Background(), id)
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, getErr)
+		assert.AreEqual(t, entry.Status, StatusPending)
+		assert.AreEqual(t, entry.Notification, notification)
+		assert.AreEqual(t, entry.NextAttemptAt, testTime)
+	})
+
+	t.Run("should return an error when the notification is invalid", func(t *testing.T) {
+		outbox := New(NewMemoryStore(), NotifierFunc(nil))
+
+		_, err := outbox.Enqueue(context.Background(), nofy.Notification{})
+
+		assert.AreEqual(t, err.Error(), "missing title or body")
+	})
+
+	t.Run("should generate ids sorted by creation time", func(t *testing.T) {
+		now := testTime
+		outbox := New(NewMemoryStore(), NotifierFunc(nil))
+		outbox.now = func() time.Time { return now }
+
+		first, _ := outbox.Enqueue(context.Background(), notification)
+		now = now.Add(time.Nanosecond)
+		second, _ := outbox.Enqueue(context.Background(), notification)
+
+		assert.AreEqual(t, first < second, true)
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	t.Run("should deliver due entries and delete them", func(t *testing.T) {
+		store := NewMemoryStore()
+		var delivered []nofy.Notification
+		outbox := New(store, NotifierFunc(
+			func(_ context.Context, n nofy.Notification) error {
+				delivered = append(delivered, n)
+				return nil
+			},
+		))
+		id, _ := outbox.Enqueue(context.Background(), notification)
+
+		count, err := outbox.Dispatch(context.Background())
+		_, getErr := store.Get(context.Background(), id)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, count, 1)
+		assert.AreEqual(t, delivered, []nofy.Notification{notification})
+		assert.AreEqual(t, errors.Is(getErr, ErrNotFound), true)
+	})
+
+	t.Run("should keep delivered entries marked done", func(t *testing.T) {
+		store := NewMemoryStore()
+		outbox := New(store, NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				return nil
+			},
+		), WithKeepDelivered())
+		id, _ := outbox.Enqueue(context.Background(), notification)
+
+		_, err := outbox.Dispatch(context.Background())
+		entry, _ := store.Get(context.Background(), id)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, entry.Status, StatusDone)
+	})
+
+	t.Run("should reschedule a failed entry with backoff", func(t *testing.T) {
+		store := NewMemoryStore()
+		now := testTime
+		outbox := New(store, NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				return errors.New("service unavailable")
+			},
+		), WithBackoff(time.Second, time.Minute))
+		outbox.now = func() time.Time { return now }
+		id, _ := outbox.Enqueue(context.Background(), notification)
+
+		count, err := outbox.Dispatch(context.Background())
+		first, _ := store.Get(context.Background(), id)
+		now = first.NextAttemptAt
+		_, _ = outbox.Dispatch(context.Background())
+		second, _ := store.Get(context.Background(), id)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, count, 0)
+		assert.AreEqual(t, first.Status, StatusPending)
+		assert.AreEqual(t, first.Attempts, 1)
+		assert.AreEqual(t, first.LastError, "service unavailable")
+		assert.AreEqual(t, first.NextAttemptAt, testTime.Add(time.Second))
+		assert.AreEqual(t, second.NextAttemptAt, testTime.Add(3*time.Second))
+	})
+
+	t.Run("should retry only the messengers that failed", func(t *testing.T) {
+		store := NewMemoryStore()
+		slack := &countingMessenger{}
+		resend := &countingMessenger{fail: true}
+		notifier := nofy.New()
+		_ = notifier.Register("slack", slack)
+		_ = notifier.Register("resend", resend)
+		now := testTime
+		outbox := New(store, Messengers(notifier), WithBackoff(time.Second, time.Minute))
+		outbox.now = func() time.Time { return now }
+		id, _ := outbox.Enqueue(context.Background(), notification)
+
+		_, _ = outbox.Dispatch(context.Background())
+		failed, _ := store.Get(context.Background(), id)
+		resend.fail = false
+		now = failed.NextAttemptAt
+		count, err := outbox.Dispatch(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, count, 1)
+		assert.AreEqual(t, failed.Delivered, []string{"slack"})
+		assert.AreEqual(t, slack.calls, 1, "Expected slack not to receive the retry")
+		assert.AreEqual(t, resend.calls, 2)
+	})
+
+	t.Run("should not deliver an entry before it is due", func(t *testing.T) {
+		calls := 0
+		outbox := New(NewMemoryStore(), NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				calls++
+				return errors.New("service unavailable")
+			},
+		))
+		_, _ = outbox.Enqueue(context.Background(), notification)
+
+		_, _ = outbox.Dispatch(context.Background())
+		_, _ = outbox.Dispatch(context.Background())
+
+		assert.AreEqual(t, calls, 1)
+	})
+
+	t.Run("should dead-letter an entry after the max attempts", func(t *testing.T) {
+		store := NewMemoryStore()
+		now := testTime
+		outbox := New(store, NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				return errors.New("service unavailable")
+			},
+		), WithMaxAttempts(2), WithBackoff(time.Second, time.Minute))
+		outbox.now = func() time.Time { return now }
+		id, _ := outbox.Enqueue(context.Background(), notification)
+
+		_, _ = outbox.Dispatch(context.Background())
+		now = now.Add(time.Minute)
+		_, _ = outbox.Dispatch(context.Background())
+		entry, _ := store.Get(context.Background(), id)
+		dead, _ := store.DeadLetters(context.Background())
+
+		assert.AreEqual(t, entry.Status, StatusDead)
+		assert.AreEqual(t, entry.Attempts, 2)
+		assert.AreEqual(t, ids(dead), []string{id})
+	})
+
+	t.Run("should limit the entries delivered per dispatch", func(t *testing.T) {
+		outbox := New(NewMemoryStore(), NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				return nil
+			},
+		), WithBatchSize(1))
+		_, _ = outbox.Enqueue(context.Background(), notification)
+		_, _ = outbox.Enqueue(context.Background(), notification)
+
+		count, err := outbox.Dispatch(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, count, 1)
+	})
+
+	t.Run("should deliver entries left pending by a previous process", func(t *testing.T) {
+		store, _ := NewFileStore(t.TempDir())
+		crashed := New(store, NotifierFunc(nil))
+		id, _ := crashed.Enqueue(context.Background(), notification)
+
+		restarted, _ := NewFileStore(store.dir)
+		outbox := New(restarted, NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				return nil
+			},
+		))
+		count, err := outbox.Dispatch(context.Background())
+		_, getErr := restarted.Get(context.Background(), id)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, count, 1)
+		assert.AreEqual(t, errors.Is(getErr, ErrNotFound), true)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("should double the delay up to the max delay", func(t *testing.T) {
+		outbox := New(NewMemoryStore(), nil, WithBackoff(time.Second, 5*time.Second))
+
+		assert.AreEqual(t, outbox.backoff(1), time.Second)
+		assert.AreEqual(t, outbox.backoff(2), 2*time.Second)
+		assert.AreEqual(t, outbox.backoff(3), 4*time.Second)
+		assert.AreEqual(t, outbox.backoff(4), 5*time.Second)
+		assert.AreEqual(t, outbox.backoff(100), 5*time.Second)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should clamp a non-positive poll interval", func(t *testing.T) {
+		outbox := New(NewMemoryStore(), NotifierFunc(nil), WithPollInterval(0))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := outbox.Run(ctx)
+
+		assert.AreEqual(t, outbox.pollInterval, time.Millisecond)
+		assert.AreEqual(t, errors.Is(err, context.Canceled), true)
+	})
+
+	t.Run("should deliver enqueued entries until the context is done", func(t *testing.T) {
+		delivered := make(chan struct{})
+		outbox := New(NewMemoryStore(), NotifierFunc(
+			func(_ context.Context, _ nofy.Notification) error {
+				close(delivered)
+				return nil
+			},
+		), WithPollInterval(time.Hour))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- outbox.Run(ctx)
+		}()
+
+		_, _ = outbox.Enqueue(context.Background(), notification)
+		<-delivered
+		cancel()
+		err := <-done
+
+		assert.AreEqual(t, errors.Is(err, context.Canceled), true)
+	})
+}
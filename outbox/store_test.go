@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestEntry(id string, status Status, nextAttemptAt time.Time) Entry {
+	return Entry{
+		ID:            id,
+		Status:        status,
+		Notification:  nofy.Notification{Title: "Deploy failed", Body: "api v1.2.3"},
+		CreatedAt:     testTime,
+		UpdatedAt:     testTime,
+		NextAttemptAt: nextAttemptAt,
+	}
+}
+
+// testStore checks the behavior every Store implementation must have.
+func testStore(t *testing.T, newStore func(t *testing.T) Store) {
+	t.Run("should get a saved entry", func(t *testing.T) {
+		store := newStore(t)
+		entry := newTestEntry("1", StatusPending, testTime)
+		entry.Notification.Metadata = map[string]string{"env": "prod"}
+
+		err := store.Save(context.Background(), entry)
+		got, getErr := store.Get(context.Background(), "1")
+
+		assert.IsNil(t, err)
+		assert.IsNil(t, getErr)
+		assert.AreEqual(t, got, entry)
+	})
+
+	t.Run("should replace a saved entry", func(t *testing.T) {
+		store := newStore(t)
+		entry := newTestEntry("1", StatusPending, testTime)
+		_ = store.Save(context.Background(), entry)
+
+		entry.Status = StatusDone
+		err := store.Save(context.Background(), entry)
+		got, _ := store.Get(context.Background(), "1")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, got.Status, StatusDone)
+	})
+
+	t.Run("should return ErrNotFound for a missing entry", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.Get(context.Background(), "missing")
+
+		assert.AreEqual(t, errors.Is(err, ErrNotFound), true)
+	})
+
+	t.Run("should delete an entry", func(t *testing.T) {
+		store := newStore(t)
+		_ = store.Save(context.Background(), newTestEntry("1", StatusDone, testTime))
+
+		err := store.Delete(context.Background(), "1")
+		_, getErr := store.Get(context.Background(), "1")
+		missingErr := store.Delete(context.Background(), "1")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, errors.Is(getErr, ErrNotFound), true)
+		assert.IsNil(t, missingErr)
+	})
+
+	t.Run("should return the due pending entries oldest first", func(t *testing.T) {
+		store := newStore(t)
+		_ = store.Save(context.Background(), newTestEntry("3", StatusPending, testTime))
+		_ = store.Save(context.Background(), newTestEntry("1", StatusPending, testTime))
+		_ = store.Save(context.Background(), newTestEntry("2", StatusDone, testTime))
+		_ = store.Save(context.Background(), newTestEntry("4", StatusDead, testTime))
+		_ = store.Save(
+			context.Background(),
+			newTestEntry("5", StatusPending, testTime.Add(time.Minute)),
+		)
+
+		entries, err := store.Due(context.Background(), testTime, 10)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"1", "3"})
+	})
+
+	t.Run("should limit the due entries", func(t *testing.T) {
+		store := newStore(t)
+		_ = store.Save(context.Background(), newTestEntry("1", StatusPending, testTime))
+		_ = store.Save(context.Background(), newTestEntry("2", StatusPending, testTime))
+
+		entries, err := store.Due(context.Background(), testTime, 1)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"1"})
+	})
+
+	t.Run("should return the dead letters", func(t *testing.T) {
+		store := newStore(t)
+		_ = store.Save(context.Background(), newTestEntry("1", StatusPending, testTime))
+		_ = store.Save(context.Background(), newTestEntry("2", StatusDead, testTime))
+
+		entries, err := store.DeadLetters(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ids(entries), []string{"2"})
+	})
+}
+
+func ids(entries []Entry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
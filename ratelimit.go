@@ -0,0 +1,36 @@
+package nofy
+
+import (
+	"context"
+
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
+)
+
+// RateLimitKey returns the key of the token bucket used for a call,
+// e.g. to limit by messenger and recipient.
+type RateLimitKey func(call Call) string
+
+// RateLimit creates a middleware that consumes a token of the limiter before every call.
+// Calls are keyed by messenger name when key is nil, so messengers of the same type
+// registered under different names in Nofy have their own bucket.
+// Depending on the limiter, calls over the limit block or fail with a *ratelimit.LimitError.
+func RateLimit(limiter *ratelimit.Limiter, key RateLimitKey) Middleware {
+	if key == nil {
+		key = func(call Call) string {
+			return call.Messenger
+		}
+	}
+
+	return Intercept(func(ctx context.Context, call Call, next func(context.Context) error) error {
+		if err := limiter.Wait(ctx, key(call)); err != nil {
+			return err
+		}
+		return next(ctx)
+	})
+}
+
+// WithRateLimit limits the calls of every messenger of Nofy,
+// keyed by the name the messenger is registered under.
+func WithRateLimit(limiter *ratelimit.Limiter) Option {
+	return WithMiddlewares(RateLimit(limiter, nil))
+}
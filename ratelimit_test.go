@@ -0,0 +1,71 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+	"github.com/lucasvillarinho/nofy/helpers/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("should fail fast when the messenger is over its limit", func(t *testing.T) {
+		limiter := ratelimit.New(time.Hour, 1, ratelimit.WithFailFast())
+		m := RateLimit(limiter, nil)(&MockMessenger{name: "slack"})
+
+		first := m.Send(context.Background())
+		second := m.Send(context.Background())
+
+		assert.IsNil(t, first)
+		assert.AreEqual(t, errors.Is(second, ratelimit.ErrLimited), true)
+	})
+
+	t.Run("should key calls with the given key", func(t *testing.T) {
+		limiter := ratelimit.New(time.Hour, 1, ratelimit.WithFailFast())
+		m := RateLimit(limiter, func(call Call) string {
+			return call.Messenger + ":" + call.Notification.Metadata["recipient"]
+		})(&MockMessenger{name: "slack"})
+
+		first := m.Notify(context.Background(), Notification{
+			Title:    "Disk full",
+			Metadata: map[string]string{"recipient": "alice"},
+		})
+		second := m.Notify(context.Background(), Notification{
+			Title:    "Disk full",
+			Metadata: map[string]string{"recipient": "bob"},
+		})
+
+		assert.IsNil(t, first)
+		assert.IsNil(t, second)
+	})
+
+	t.Run("should limit every messenger of Nofy", func(t *testing.T) {
+		limiter := ratelimit.New(time.Hour, 1, ratelimit.WithFailFast())
+		s := New(
+			WithMessengers(&MockMessenger{name: "slack"}, &MockMessenger{name: "resend"}),
+			WithRateLimit(limiter),
+		)
+
+		first := s.SendAll(context.Background())
+		second := s.SendAllResult(context.Background())
+
+		assert.IsNil(t, first)
+		assert.AreEqual(t, len(second.Failed()), 2)
+		assert.AreEqual(t, errors.Is(second.Err(), ratelimit.ErrLimited), true)
+	})
+
+	t.Run("should key messengers of Nofy by their registered name", func(t *testing.T) {
+		limiter := ratelimit.New(time.Hour, 1, ratelimit.WithFailFast())
+		s := New(WithRateLimit(limiter))
+		_ = s.Register("payments", &MockMessenger{name: "slack"})
+		_ = s.Register("infra", &MockMessenger{name: "slack"})
+
+		result := s.SendAllResult(context.Background())
+
+		assert.IsNil(t, result.Err())
+		assert.AreEqual(t, result.Results[0].Messenger, "payments")
+		assert.AreEqual(t, result.Results[1].Messenger, "infra")
+	})
+}
@@ -0,0 +1,197 @@
+package nofy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrMessengerExists is returned when registering a name that is already registered.
+	ErrMessengerExists = errors.New("messenger already registered")
+	// ErrMessengerNotFound is returned when no messenger is registered under a name.
+	ErrMessengerNotFound = errors.New("messenger not found")
+)
+
+// Registration is a messenger registered in Nofy under a unique name.
+// Disabled messengers stay registered but are not called.
+type Registration struct {
+	Messenger Messenger
+	Name      string
+	Enabled   bool
+}
+
+// registry holds the registrations and middlewares of Nofy.
+// Changes copy them and swap them atomically,
+// so sends in flight keep the snapshot they started with.
+type registry struct {
+	registrations atomic.Pointer[[]Registration]
+	middlewares   atomic.Pointer[[]Middleware]
+	mu            sync.Mutex
+}
+
+// snapshot returns the current registrations, which must not be modified.
+func (r *registry) snapshot() []Registration {
+	if registrations := r.registrations.Load(); registrations != nil {
+		return *registrations
+	}
+	return nil
+}
+
+// update applies change to a copy of the registrations and stores the copy.
+func (r *registry) update(change func([]Registration) ([]Registration, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.snapshot()
+	registrations, err := change(append(make([]Registration, 0, len(current)+1), current...))
+	if err != nil {
+		return err
+	}
+
+	r.registrations.Store(&registrations)
+	return nil
+}
+
+// middlewareSnapshot returns the current middlewares, which must not be modified.
+func (r *registry) middlewareSnapshot() []Middleware {
+	if middlewares := r.middlewares.Load(); middlewares != nil {
+		return *middlewares
+	}
+	return nil
+}
+
+// use stores a copy of the middlewares with the given ones appended.
+func (r *registry) use(middlewares []Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.middlewareSnapshot()
+	updated := append(make([]Middleware, 0, len(current)+len(middlewares)), current...)
+	updated = append(updated, middlewares...)
+	r.middlewares.Store(&updated)
+}
+
+// Register adds the messenger under a unique name.
+// It returns an error wrapping ErrMessengerExists when the name is taken.
+func (s *Nofy) Register(name string, m Messenger) error {
+	if name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if m == nil {
+		return fmt.Errorf("missing messenger")
+	}
+
+	return s.registry.update(func(registrations []Registration) ([]Registration, error) {
+		if indexOf(registrations, name) >= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrMessengerExists, name)
+		}
+		return append(registrations, Registration{Name: name, Messenger: m, Enabled: true}), nil
+	})
+}
+
+// Get returns the messenger registered under the name.
+func (s *Nofy) Get(name string) (Messenger, bool) {
+	registrations := s.registry.snapshot()
+	if i := indexOf(registrations, name); i >= 0 {
+		return registrations[i].Messenger, true
+	}
+	return nil, false
+}
+
+// Remove removes the messenger registered under the name.
+// Sends in flight still deliver through it.
+func (s *Nofy) Remove(name string) error {
+	return s.registry.update(func(registrations []Registration) ([]Registration, error) {
+		i := indexOf(registrations, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrMessengerNotFound, name)
+		}
+		return append(registrations[:i], registrations[i+1:]...), nil
+	})
+}
+
+// List returns the registrations in the order they were registered.
+func (s *Nofy) List() []Registration {
+	return append([]Registration(nil), s.registry.snapshot()...)
+}
+
+// Enable lets the messenger registered under the name be called again.
+func (s *Nofy) Enable(name string) error {
+	return s.setEnabled(name, true)
+}
+
+// Disable stops calling the messenger registered under the name until it is enabled again,
+// e.g. during a maintenance of the service.
+func (s *Nofy) Disable(name string) error {
+	return s.setEnabled(name, false)
+}
+
+func (s *Nofy) setEnabled(name string, enabled bool) error {
+	return s.registry.update(func(registrations []Registration) ([]Registration, error) {
+		i := indexOf(registrations, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrMessengerNotFound, name)
+		}
+		registrations[i].Enabled = enabled
+		return registrations, nil
+	})
+}
+
+// AddMessenger registers the messenger under its name, see NameOf.
+// A suffix is added when the name is taken, e.g. slack-2.
+func (s *Nofy) AddMessenger(m Messenger) {
+	_ = s.registry.update(func(registrations []Registration) ([]Registration, error) {
+		name := NameOf(m)
+		for i := 2; indexOf(registrations, name) >= 0; i++ {
+			name = fmt.Sprintf("%s-%d", NameOf(m), i)
+		}
+		return append(registrations, Registration{Name: name, Messenger: m, Enabled: true}), nil
+	})
+}
+
+// RemoveMessenger removes the first registration of the messenger.
+//
+// Deprecated: use Remove with the name of the messenger.
+func (s *Nofy) RemoveMessenger(m Messenger) {
+	_ = s.registry.update(func(registrations []Registration) ([]Registration, error) {
+		for i, registration := range registrations {
+			if sameMessenger(registration.Messenger, m) {
+				return append(registrations[:i], registrations[i+1:]...), nil
+			}
+		}
+		return registrations, nil
+	})
+}
+
+// named names a messenger after its registration or its route,
+// so that results, logs and middlewares see that name instead of the messenger's own.
+type named struct {
+	Messenger
+	name string
+}
+
+func (n *named) Name() string {
+	return n.name
+}
+
+func indexOf(registrations []Registration, name string) int {
+	for i, registration := range registrations {
+		if registration.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameMessenger compares messengers without panicking on non-comparable types,
+// which are never considered the same.
+func sameMessenger(a, b Messenger) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
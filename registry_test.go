@@ -0,0 +1,198 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+// valueMessenger is not comparable, comparing it with == panics.
+type valueMessenger struct {
+	channels []string
+}
+
+func (m valueMessenger) Send(_ context.Context) error {
+	return nil
+}
+
+func (m valueMessenger) Notify(_ context.Context, _ Notification) error {
+	return nil
+}
+
+func names(registrations []Registration) []string {
+	names := make([]string, 0, len(registrations))
+	for _, registration := range registrations {
+		names = append(names, registration.Name)
+	}
+	return names
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should register messengers under their names", func(t *testing.T) {
+		s := New()
+		slack := &MockMessenger{}
+
+		err := s.Register("payments-slack", slack)
+		_ = s.Register("oncall-email", &MockMessenger{})
+		got, ok := s.Get("payments-slack")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, ok, true)
+		assert.AreEqual(t, got, Messenger(slack))
+		assert.AreEqual(t, names(s.List()), []string{"payments-slack", "oncall-email"})
+	})
+
+	t.Run("should return an error when the name is taken", func(t *testing.T) {
+		s := New()
+		_ = s.Register("slack", &MockMessenger{})
+
+		err := s.Register("slack", &MockMessenger{})
+
+		assert.AreEqual(t, errors.Is(err, ErrMessengerExists), true)
+		assert.AreEqual(t, err.Error(), "messenger already registered: slack")
+	})
+
+	t.Run("should return an error when the name or messenger is missing", func(t *testing.T) {
+		s := New()
+
+		nameErr := s.Register("", &MockMessenger{})
+		messengerErr := s.Register("slack", nil)
+
+		assert.AreEqual(t, nameErr.Error(), "missing name")
+		assert.AreEqual(t, messengerErr.Error(), "missing messenger")
+	})
+
+	t.Run("should not find a messenger that is not registered", func(t *testing.T) {
+		_, ok := New().Get("slack")
+
+		assert.AreEqual(t, ok, false)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	t.Run("should remove the messenger registered under the name", func(t *testing.T) {
+		s := New()
+		_ = s.Register("slack", &MockMessenger{})
+		_ = s.Register("email", &MockMessenger{})
+
+		err := s.Remove("slack")
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, names(s.List()), []string{"email"})
+	})
+
+	t.Run("should return an error when the name is not registered", func(t *testing.T) {
+		err := New().Remove("slack")
+
+		assert.AreEqual(t, errors.Is(err, ErrMessengerNotFound), true)
+	})
+}
+
+func TestEnableDisable(t *testing.T) {
+	t.Run("should not call disabled messengers", func(t *testing.T) {
+		calls := 0
+		s := New()
+		_ = s.Register("slack", &MockMessenger{sendFunc: func(_ context.Context) error {
+			calls++
+			return nil
+		}})
+		_ = s.Register("email", &MockMessenger{})
+
+		err := s.Disable("slack")
+		result := s.SendAllResult(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, calls, 0)
+		assert.AreEqual(t, len(result.Results), 1)
+		assert.AreEqual(t, result.Results[0].Messenger, "email")
+		assert.AreEqual(t, s.List()[0].Enabled, false)
+	})
+
+	t.Run("should call messengers enabled again", func(t *testing.T) {
+		calls := 0
+		s := New()
+		_ = s.Register("slack", &MockMessenger{sendFunc: func(_ context.Context) error {
+			calls++
+			return nil
+		}})
+
+		_ = s.Disable("slack")
+		err := s.Enable("slack")
+		_ = s.SendAll(context.Background())
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, calls, 1)
+	})
+
+	t.Run("should return an error when the name is not registered", func(t *testing.T) {
+		err := New().Disable("slack")
+
+		assert.AreEqual(t, err.Error(), "messenger not found: slack")
+	})
+}
+
+func TestRegistryNames(t *testing.T) {
+	t.Run("should name added messengers uniquely", func(t *testing.T) {
+		s := New()
+
+		s.AddMessenger(&MockMessenger{name: "slack"})
+		s.AddMessenger(&MockMessenger{name: "slack"})
+		s.AddMessenger(&MockMessenger{name: "slack"})
+
+		assert.AreEqual(t, names(s.List()), []string{"slack", "slack-2", "slack-3"})
+	})
+
+	t.Run("should report results under the registered name", func(t *testing.T) {
+		s := New()
+		_ = s.Register("payments-slack", &MockMessenger{
+			name: "slack",
+			sendFunc: func(_ context.Context) error {
+				return errors.New("channel_not_found")
+			},
+		})
+
+		err := s.SendAll(context.Background())
+
+		assert.AreEqual(t, err.Error(), "errors: payments-slack: channel_not_found")
+	})
+
+	t.Run("should not panic removing non-comparable messengers", func(t *testing.T) {
+		s := New()
+		m := valueMessenger{channels: []string{"alerts"}}
+		s.AddMessenger(m)
+
+		s.RemoveMessenger(m)
+
+		assert.AreEqual(t, len(s.List()), 1)
+	})
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+	t.Run("should register and remove messengers while sending", func(t *testing.T) {
+		s := New()
+		_ = s.Register("slack", &MockMessenger{})
+		var wg sync.WaitGroup
+
+		for i := range 20 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				name := fmt.Sprintf("email-%d", i)
+				_ = s.Register(name, &MockMessenger{})
+				_ = s.Disable(name)
+				_ = s.Remove(name)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = s.SendAll(context.Background())
+			}()
+		}
+		wg.Wait()
+
+		assert.AreEqual(t, names(s.List()), []string{"slack"})
+	})
+}
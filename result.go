@@ -0,0 +1,112 @@
+package nofy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Namer is implemented by messengers that have a name to identify them
+// in results and logs.
+type Namer interface {
+	Name() string
+}
+
+// NameOf returns the name of the messenger.
+// It falls back to the type of the messenger when it does not implement Namer.
+func NameOf(m Messenger) string {
+	if namer, ok := m.(Namer); ok && namer.Name() != "" {
+		return namer.Name()
+	}
+	return fmt.Sprintf("%T", m)
+}
+
+// Result is the outcome of delivering through a single messenger.
+// Messenger is the name the messenger is registered under and Index its position in Nofy.
+// Err is nil when the delivery succeeded.
+// Duration is how long the delivery took and Attempts how many requests were made.
+type Result struct {
+	Err       error
+	Messenger string
+	Index     int
+	Duration  time.Duration
+	Attempts  int
+}
+
+// OK returns true when the delivery succeeded.
+func (r Result) OK() bool {
+	return r.Err == nil
+}
+
+// MessengerError is the error returned by a messenger, wrapped with its identity.
+type MessengerError struct {
+	Err       error
+	Messenger string
+	Index     int
+}
+
+func (e *MessengerError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Messenger, e.Err)
+}
+
+func (e *MessengerError) Unwrap() error {
+	return e.Err
+}
+
+// SendResult records the outcome of every messenger of a SendAll or NotifyAll call.
+// When at least one messenger failed it is returned as an error that
+// follows errors.Join semantics: errors.Is and errors.As inspect the
+// *MessengerError of every failed messenger and the errors they wrap.
+type SendResult struct {
+	Results []Result
+}
+
+// Err returns the result as an error when at least one messenger failed, nil otherwise.
+func (r *SendResult) Err() error {
+	if len(r.Failed()) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Failed returns the results of the messengers that failed.
+func (r *SendResult) Failed() []Result {
+	return r.filter(false)
+}
+
+// Succeeded returns the results of the messengers that succeeded.
+func (r *SendResult) Succeeded() []Result {
+	return r.filter(true)
+}
+
+func (r *SendResult) filter(ok bool) []Result {
+	results := make([]Result, 0, len(r.Results))
+	for _, result := range r.Results {
+		if result.OK() == ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func (r *SendResult) Error() string {
+	errs := r.Unwrap()
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("errors: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns a *MessengerError for every messenger that failed.
+func (r *SendResult) Unwrap() []error {
+	errs := make([]error, 0, len(r.Results))
+	for _, result := range r.Failed() {
+		errs = append(errs, &MessengerError{
+			Err:       result.Err,
+			Messenger: result.Messenger,
+			Index:     result.Index,
+		})
+	}
+	return errs
+}
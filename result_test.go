@@ -0,0 +1,62 @@
+package nofy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+func TestNameOf(t *testing.T) {
+	t.Run("should return the name of a Namer", func(t *testing.T) {
+		assert.AreEqual(t, NameOf(&MockMessenger{name: "mock"}), "mock")
+	})
+
+	t.Run("should fall back to the type when the name is empty", func(t *testing.T) {
+		assert.AreEqual(t, NameOf(&MockMessenger{}), "*nofy.MockMessenger")
+	})
+
+	t.Run("should fall back to the type when Namer is not implemented", func(t *testing.T) {
+		var m Messenger = struct{ Messenger }{&MockMessenger{name: "mock"}}
+
+		assert.AreEqual(t, NameOf(m), "struct { nofy.Messenger }")
+	})
+}
+
+func TestMessengerError(t *testing.T) {
+	t.Run("should prefix the error with the messenger name", func(t *testing.T) {
+		err := &MessengerError{Messenger: "slack", Err: errors.New("failed")}
+
+		assert.AreEqualErrs(t, err, errors.New("slack: failed"))
+	})
+
+	t.Run("should unwrap the original error", func(t *testing.T) {
+		original := errors.New("failed")
+		err := &MessengerError{Messenger: "slack", Err: original}
+
+		assert.AreEqual(t, errors.Unwrap(err), original)
+	})
+}
+
+func TestSendResult(t *testing.T) {
+	t.Run("should join the errors of failed messengers", func(t *testing.T) {
+		result := &SendResult{
+			Results: []Result{
+				{Messenger: "slack", Err: errors.New("first error")},
+				{Messenger: "webhook"},
+				{Messenger: "resend", Err: errors.New("second error")},
+			},
+		}
+
+		err := result.Err()
+
+		assert.AreEqualErrs(t, err, errors.New("errors: slack: first error; resend: second error"))
+		assert.AreEqual(t, len(result.Unwrap()), 2)
+	})
+
+	t.Run("should return nil when no messenger failed", func(t *testing.T) {
+		result := &SendResult{Results: []Result{{Messenger: "slack"}}}
+
+		assert.IsNil(t, result.Err())
+	})
+}
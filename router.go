@@ -0,0 +1,189 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrNoRoute is returned when no route matches a notification and there is no default route.
+var ErrNoRoute = errors.New("no route matched")
+
+// Matcher reports whether a notification matches a route.
+type Matcher func(n Notification) bool
+
+// SeverityAtLeast matches notifications whose severity is at least the given one.
+func SeverityAtLeast(severity Severity) Matcher {
+	return func(n Notification) bool {
+		return n.Severity >= severity
+	}
+}
+
+// LabelEquals matches notifications whose metadata has the key set to the value,
+// e.g. LabelEquals("team", "payments").
+func LabelEquals(key, value string) Matcher {
+	return func(n Notification) bool {
+		label, ok := n.Metadata[key]
+		return ok && label == value
+	}
+}
+
+// LabelMatches matches notifications whose metadata has the key set to a value matching re.
+func LabelMatches(key string, re *regexp.Regexp) Matcher {
+	return func(n Notification) bool {
+		label, ok := n.Metadata[key]
+		return ok && re.MatchString(label)
+	}
+}
+
+// TitleMatches matches notifications whose title matches re.
+func TitleMatches(re *regexp.Regexp) Matcher {
+	return func(n Notification) bool {
+		return re.MatchString(n.Title)
+	}
+}
+
+// Route delivers the notifications matching all of its matchers through its messenger.
+// A route without matchers matches every notification.
+// Continue keeps evaluating the following routes once this one matched.
+type Route struct {
+	Messenger Messenger
+	Name      string
+	Matchers  []Matcher
+	Continue  bool
+}
+
+// Match reports whether the notification matches all the matchers of the route.
+func (r Route) Match(n Notification) bool {
+	for _, match := range r.Matchers {
+		if !match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// Router delivers each notification only through the messengers of the routes it matches.
+// Routes are evaluated in order and, like Alertmanager routes, evaluation stops at the
+// first matching route unless it has Continue set.
+// The default route is used when no route matched.
+type Router struct {
+	defaultRoute *Route
+	routes       []Route
+	options      []Option
+}
+
+type RouterOption func(*Router)
+
+// NewRouter creates a router with the routes.
+// It returns an error when a route has no name or messenger
+// or when two routes have the same name.
+func NewRouter(options ...RouterOption) (*Router, error) {
+	router := &Router{}
+
+	for _, opt := range options {
+		opt(router)
+	}
+
+	if err := router.validate(); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
+
+// WithRoute adds a route, evaluated after the routes added before it.
+func WithRoute(route Route) RouterOption {
+	return func(r *Router) {
+		r.routes = append(r.routes, route)
+	}
+}
+
+// WithDefaultRoute sets the messenger of the notifications that matched no route.
+func WithDefaultRoute(name string, m Messenger) RouterOption {
+	return func(r *Router) {
+		r.defaultRoute = &Route{Name: name, Messenger: m}
+	}
+}
+
+// WithRouterOptions sets the options of the Nofy used to deliver the matched routes,
+// e.g. WithLogger, WithMaxConcurrency or WithMiddlewares.
+func WithRouterOptions(options ...Option) RouterOption {
+	return func(r *Router) {
+		r.options = append(r.options, options...)
+	}
+}
+
+func (r *Router) validate() error {
+	routes := r.routes
+	if r.defaultRoute != nil {
+		routes = append(routes[:len(routes):len(routes)], *r.defaultRoute)
+	}
+
+	names := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if route.Name == "" {
+			return fmt.Errorf("missing route name")
+		}
+		if route.Messenger == nil {
+			return fmt.Errorf("missing messenger for route %q", route.Name)
+		}
+		if names[route.Name] {
+			return fmt.Errorf("duplicate route %q", route.Name)
+		}
+		names[route.Name] = true
+	}
+
+	return nil
+}
+
+// Match returns the routes the notification is delivered to.
+func (r *Router) Match(n Notification) []Route {
+	matched := make([]Route, 0)
+	for _, route := range r.routes {
+		if !route.Match(n) {
+			continue
+		}
+
+		matched = append(matched, route)
+		if !route.Continue {
+			break
+		}
+	}
+
+	if len(matched) == 0 && r.defaultRoute != nil {
+		matched = append(matched, *r.defaultRoute)
+	}
+
+	return matched
+}
+
+// Notify delivers the notification through the messengers of the matching routes.
+// It returns ErrNoRoute when no route matched
+// and a *SendResult when at least one messenger failed.
+func (r *Router) Notify(ctx context.Context, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	result := r.NotifyResult(ctx, n)
+	if len(result.Results) == 0 {
+		return ErrNoRoute
+	}
+	return result.Err()
+}
+
+// NotifyResult delivers the notification through the messengers of the matching routes
+// and returns the outcome of every route, named after the route.
+func (r *Router) NotifyResult(ctx context.Context, n Notification) *SendResult {
+	routes := r.Match(n)
+
+	messengers := make([]Messenger, 0, len(routes))
+	for _, route := range routes {
+		messengers = append(messengers, &named{Messenger: route.Messenger, name: route.Name})
+	}
+
+	options := append(r.options[:len(r.options):len(r.options)], WithMessengers(messengers...))
+	return New(options...).NotifyAllResult(ctx, n)
+}
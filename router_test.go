@@ -0,0 +1,205 @@
+package nofy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+// routeRecorder records which messengers received a notification.
+type routeRecorder struct {
+	names []string
+	mu    sync.Mutex
+}
+
+func (r *routeRecorder) messenger(name string) Messenger {
+	return &MockMessenger{
+		name: name,
+		notifyFunc: func(_ context.Context, _ Notification) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.names = append(r.names, name)
+			return nil
+		},
+	}
+}
+
+func (r *routeRecorder) delivered() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sort.Strings(r.names)
+	return r.names
+}
+
+func TestMatchers(t *testing.T) {
+	t.Run("should match the severity", func(t *testing.T) {
+		match := SeverityAtLeast(SeverityError)
+
+		assert.AreEqual(t, match(Notification{Severity: SeverityCritical}), true)
+		assert.AreEqual(t, match(Notification{Severity: SeverityError}), true)
+		assert.AreEqual(t, match(Notification{Severity: SeverityWarning}), false)
+	})
+
+	t.Run("should match the labels", func(t *testing.T) {
+		equals := LabelEquals("team", "payments")
+		matches := LabelMatches("team", regexp.MustCompile("^pay"))
+		payments := Notification{Metadata: map[string]string{"team": "payments"}}
+		search := Notification{Metadata: map[string]string{"team": "search"}}
+
+		assert.AreEqual(t, equals(payments), true)
+		assert.AreEqual(t, equals(search), false)
+		assert.AreEqual(t, equals(Notification{}), false)
+		assert.AreEqual(t, matches(payments), true)
+		assert.AreEqual(t, matches(search), false)
+	})
+
+	t.Run("should match the title", func(t *testing.T) {
+		match := TitleMatches(regexp.MustCompile(`(?i)database`))
+
+		assert.AreEqual(t, match(Notification{Title: "Database down"}), true)
+		assert.AreEqual(t, match(Notification{Title: "Disk full"}), false)
+	})
+}
+
+func TestRouter(t *testing.T) {
+	t.Run("should deliver only to the first matching route", func(t *testing.T) {
+		var recorder routeRecorder
+		router, _ := NewRouter(
+			WithRoute(Route{
+				Name:      "payments",
+				Messenger: recorder.messenger("payments"),
+				Matchers:  []Matcher{LabelEquals("team", "payments")},
+			}),
+			WithRoute(Route{
+				Name:      "errors",
+				Messenger: recorder.messenger("errors"),
+				Matchers:  []Matcher{SeverityAtLeast(SeverityError)},
+			}),
+		)
+
+		err := router.Notify(context.Background(), Notification{
+			Title:    "Refund failed",
+			Severity: SeverityError,
+			Metadata: map[string]string{"team": "payments"},
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, recorder.delivered(), []string{"payments"})
+	})
+
+	t.Run("should keep evaluating routes that continue", func(t *testing.T) {
+		var recorder routeRecorder
+		router, _ := NewRouter(
+			WithRoute(Route{
+				Name:      "audit",
+				Messenger: recorder.messenger("audit"),
+				Continue:  true,
+			}),
+			WithRoute(Route{
+				Name:      "errors",
+				Messenger: recorder.messenger("errors"),
+				Matchers:  []Matcher{SeverityAtLeast(SeverityError)},
+			}),
+		)
+
+		err := router.Notify(context.Background(), Notification{
+			Title:    "Refund failed",
+			Severity: SeverityError,
+		})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, recorder.delivered(), []string{"audit", "errors"})
+	})
+
+	t.Run("should require every matcher of a route", func(t *testing.T) {
+		router, _ := NewRouter(
+			WithRoute(Route{
+				Name:      "payments-errors",
+				Messenger: &MockMessenger{},
+				Matchers: []Matcher{
+					LabelEquals("team", "payments"),
+					SeverityAtLeast(SeverityError),
+				},
+			}),
+		)
+
+		routes := router.Match(Notification{
+			Title:    "Refund slow",
+			Severity: SeverityWarning,
+			Metadata: map[string]string{"team": "payments"},
+		})
+
+		assert.AreEqual(t, len(routes), 0)
+	})
+
+	t.Run("should deliver to the default route when no route matched", func(t *testing.T) {
+		var recorder routeRecorder
+		router, _ := NewRouter(
+			WithRoute(Route{
+				Name:      "errors",
+				Messenger: recorder.messenger("errors"),
+				Matchers:  []Matcher{SeverityAtLeast(SeverityError)},
+			}),
+			WithDefaultRoute("default", recorder.messenger("default")),
+		)
+
+		err := router.Notify(context.Background(), Notification{Title: "Deploy finished"})
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, recorder.delivered(), []string{"default"})
+	})
+
+	t.Run("should return ErrNoRoute when no route matched", func(t *testing.T) {
+		router, _ := NewRouter()
+
+		err := router.Notify(context.Background(), Notification{Title: "Deploy finished"})
+
+		assert.AreEqual(t, errors.Is(err, ErrNoRoute), true)
+	})
+
+	t.Run("should name the results after the routes", func(t *testing.T) {
+		router, _ := NewRouter(
+			WithRoute(Route{
+				Name: "payments-oncall",
+				Messenger: &MockMessenger{
+					name: "slack",
+					notifyFunc: func(_ context.Context, _ Notification) error {
+						return errors.New("channel_not_found")
+					},
+				},
+			}),
+		)
+
+		result := router.NotifyResult(context.Background(), Notification{Title: "Refund failed"})
+
+		assert.AreEqual(t, result.Error(), "errors: payments-oncall: channel_not_found")
+	})
+
+	t.Run("should return an error when a route has no name", func(t *testing.T) {
+		_, routeErr := NewRouter(WithRoute(Route{Messenger: &MockMessenger{}}))
+		_, defaultErr := NewRouter(WithDefaultRoute("", &MockMessenger{}))
+
+		assert.AreEqual(t, routeErr.Error(), "missing route name")
+		assert.AreEqual(t, defaultErr.Error(), "missing route name")
+	})
+
+	t.Run("should return an error when a route has no messenger", func(t *testing.T) {
+		_, err := NewRouter(WithRoute(Route{Name: "payments"}))
+
+		assert.AreEqual(t, err.Error(), `missing messenger for route "payments"`)
+	})
+
+	t.Run("should return an error when two routes have the same name", func(t *testing.T) {
+		_, err := NewRouter(
+			WithRoute(Route{Name: "payments", Messenger: &MockMessenger{}}),
+			WithDefaultRoute("payments", &MockMessenger{}),
+		)
+
+		assert.AreEqual(t, err.Error(), `duplicate route "payments"`)
+	})
+}
@@ -0,0 +1,234 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/lucasvillarinho/nofy"
+)
+
+// Templates is a set of named templates rendering notification data
+// into Slack blocks, email HTML and plain text.
+// Templates whose name ends with .html are HTML templates, escaped for email bodies;
+// the others are text templates, used for plain text and JSON.
+// Every template can use the json function to write a value as a JSON literal,
+// e.g. {"type": "plain_text", "text": {{json .Title}}}.
+// Missing map keys, such as an unknown metadata label, are reported as errors.
+type Templates struct {
+	text   map[string]*texttemplate.Template
+	html   map[string]*htmltemplate.Template
+	sample any
+	funcs  map[string]any
+}
+
+type Option func(*Templates)
+
+// New creates an empty set of templates.
+func New(options ...Option) *Templates {
+	templates := &Templates{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+		funcs: map[string]any{
+			"json": toJSON,
+		},
+	}
+
+	for _, opt := range options {
+		opt(templates)
+	}
+
+	return templates
+}
+
+// WithFuncs adds functions that templates can call.
+// They must be set before loading the templates that use them.
+func WithFuncs(funcs map[string]any) Option {
+	return func(t *Templates) {
+		for name, fn := range funcs {
+			t.funcs[name] = fn
+		}
+	}
+}
+
+// WithSampleData sets the data templates are rendered with when loaded,
+// so that unknown fields and missing keys are reported at load time
+// instead of when rendering, e.g. a nofy.Notification with every metadata label set.
+func WithSampleData(data any) Option {
+	return func(t *Templates) {
+		t.sample = data
+	}
+}
+
+// Add parses the template source and adds it under the name.
+// Syntax errors, unknown functions and, with WithSampleData, rendering errors are returned.
+func (t *Templates) Add(name, source string) error {
+	if name == "" {
+		return fmt.Errorf("missing name")
+	}
+
+	if isHTML(name) {
+		tmpl, err := htmltemplate.New(name).
+			Option("missingkey=error").
+			Funcs(t.funcs).
+			Parse(source)
+		if err != nil {
+			return fmt.Errorf("error parsing template %s: %w", name, err)
+		}
+		if err := t.validate(name, tmpl); err != nil {
+			return err
+		}
+		t.html[name] = tmpl
+		return nil
+	}
+
+	tmpl, err := texttemplate.New(name).
+		Option("missingkey=error").
+		Funcs(t.funcs).
+		Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template %s: %w", name, err)
+	}
+	if err := t.validate(name, tmpl); err != nil {
+		return err
+	}
+	t.text[name] = tmpl
+	return nil
+}
+
+// ParseFiles adds the files, each named after its base name, e.g. alert.html.
+func (t *Templates) ParseFiles(filenames ...string) error {
+	for _, filename := range filenames {
+		source, err := os.ReadFile(filename) // #nosec G304 -- files are chosen by the caller
+		if err != nil {
+			return fmt.Errorf("error reading template: %w", err)
+		}
+		if err := t.Add(filepath.Base(filename), string(source)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseFS adds the files of fsys matching the patterns, e.g. an embed.FS,
+// each named after its base name.
+func (t *Templates) ParseFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		filenames, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return fmt.Errorf("error matching templates: %w", err)
+		}
+		if len(filenames) == 0 {
+			return fmt.Errorf("no templates match %s", pattern)
+		}
+
+		for _, filename := range filenames {
+			source, err := fs.ReadFile(fsys, filename)
+			if err != nil {
+				return fmt.Errorf("error reading template: %w", err)
+			}
+			if err := t.Add(path.Base(filename), string(source)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Text renders the text template with the data.
+func (t *Templates) Text(name string, data any) (string, error) {
+	tmpl, ok := t.text[name]
+	if !ok {
+		return "", fmt.Errorf("missing text template %s", name)
+	}
+	return execute(tmpl, data)
+}
+
+// HTML renders the HTML template with the data.
+func (t *Templates) HTML(name string, data any) (string, error) {
+	tmpl, ok := t.html[name]
+	if !ok {
+		return "", fmt.Errorf("missing html template %s", name)
+	}
+	return execute(tmpl, data)
+}
+
+// Blocks renders the text template with the data into Slack blocks.
+// The template must render a JSON array of blocks or an object with a blocks array,
+// as exported by the Block Kit Builder.
+func (t *Templates) Blocks(name string, data any) ([]map[string]any, error) {
+	rendered, err := t.Text(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered = strings.TrimSpace(rendered)
+	if strings.HasPrefix(rendered, "{") {
+		var payload struct {
+			Blocks []map[string]any `json:"blocks"`
+		}
+		if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+			return nil, fmt.Errorf("error unmarshalling blocks of template %s: %w", name, err)
+		}
+		return payload.Blocks, nil
+	}
+
+	var blocks []map[string]any
+	if err := json.Unmarshal([]byte(rendered), &blocks); err != nil {
+		return nil, fmt.Errorf("error unmarshalling blocks of template %s: %w", name, err)
+	}
+	return blocks, nil
+}
+
+// BlocksRenderer returns a function rendering notifications into Slack blocks
+// with the template, e.g. for slack.WithRenderer.
+func (t *Templates) BlocksRenderer(name string) func(n nofy.Notification) ([]map[string]any, error) {
+	return func(n nofy.Notification) ([]map[string]any, error) {
+		return t.Blocks(name, n)
+	}
+}
+
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// validate renders the template with the sample data, if any.
+func (t *Templates) validate(name string, tmpl executor) error {
+	if t.sample == nil {
+		return nil
+	}
+	if err := tmpl.Execute(io.Discard, t.sample); err != nil {
+		return fmt.Errorf("error validating template %s: %w", name, err)
+	}
+	return nil
+}
+
+func execute(tmpl executor, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func isHTML(name string) bool {
+	ext := path.Ext(strings.TrimSuffix(name, ".tmpl"))
+	return ext == ".html" || ext == ".htm"
+}
+
+// toJSON writes the value as a JSON literal, escaping strings.
+func toJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
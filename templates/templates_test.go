@@ -0,0 +1,197 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lucasvillarinho/nofy"
+	"github.com/lucasvillarinho/nofy/helpers/assert"
+)
+
+var notification = nofy.Notification{
+	Title:    "Deploy <failed>",
+	Body:     "api \"v1.2.3\"",
+	Metadata: map[string]string{"team": "payments"},
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("should return an error when the template is invalid", func(t *testing.T) {
+		err := New().Add("alert.txt", "{{.Title")
+
+		assert.AreEqual(t, strings.HasPrefix(err.Error(), "error parsing template alert.txt:"), true)
+	})
+
+	t.Run("should return an error when a function is unknown", func(t *testing.T) {
+		err := New().Add("alert.txt", "{{upper .Title}}")
+
+		assert.AreEqual(t, strings.Contains(err.Error(), `function "upper" not defined`), true)
+	})
+
+	t.Run("should allow the functions set", func(t *testing.T) {
+		templates := New(WithFuncs(map[string]any{"upper": strings.ToUpper}))
+
+		err := templates.Add("alert.txt", "{{upper .Title}}")
+		text, _ := templates.Text("alert.txt", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, text, "DEPLOY <FAILED>")
+	})
+
+	t.Run("should validate templates with the sample data", func(t *testing.T) {
+		templates := New(WithSampleData(nofy.Notification{
+			Metadata: map[string]string{"team": ""},
+		}))
+
+		validErr := templates.Add("alert.txt", "{{.Title}} {{.Metadata.team}}")
+		fieldErr := templates.Add("typo.txt", "{{.Titel}}")
+		keyErr := templates.Add("key.txt", "{{.Metadata.service}}")
+
+		assert.IsNil(t, validErr)
+		assert.AreEqual(t, strings.HasPrefix(fieldErr.Error(), "error validating template typo.txt:"), true)
+		assert.AreEqual(t, strings.Contains(keyErr.Error(), `map has no entry for key "service"`), true)
+	})
+
+	t.Run("should return an error when the name is missing", func(t *testing.T) {
+		err := New().Add("", "{{.Title}}")
+
+		assert.AreEqual(t, err.Error(), "missing name")
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("should render plain text", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.txt", "{{.Title}}: {{.Body}}")
+
+		text, err := templates.Text("alert.txt", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, text, `Deploy <failed>: api "v1.2.3"`)
+	})
+
+	t.Run("should escape html", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.html", "<h1>{{.Title}}</h1>")
+
+		html, err := templates.HTML("alert.html", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, html, "<h1>Deploy &lt;failed&gt;</h1>")
+	})
+
+	t.Run("should report missing variables", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.txt", "{{.Metadata.service}}")
+
+		_, err := templates.Text("alert.txt", notification)
+
+		assert.AreEqual(t, strings.Contains(err.Error(), `map has no entry for key "service"`), true)
+	})
+
+	t.Run("should return an error when the template is missing", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.html", "<h1>{{.Title}}</h1>")
+
+		_, textErr := templates.Text("alert.html", notification)
+		_, htmlErr := templates.HTML("missing.html", notification)
+
+		assert.AreEqual(t, textErr.Error(), "missing text template alert.html")
+		assert.AreEqual(t, htmlErr.Error(), "missing html template missing.html")
+	})
+}
+
+func TestBlocks(t *testing.T) {
+	t.Run("should render a json array of blocks", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.json", `[
+			{"type": "header", "text": {"type": "plain_text", "text": {{json .Title}}}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": {{json .Body}}}}
+		]`)
+
+		blocks, err := templates.Blocks("alert.json", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, blocks, []map[string]any{
+			{"type": "header", "text": map[string]any{"type": "plain_text", "text": "Deploy <failed>"}},
+			{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": `api "v1.2.3"`}},
+		})
+	})
+
+	t.Run("should render a block kit builder payload", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.json", `{"blocks": [{"type": "divider"}]}`)
+
+		blocks, err := templates.Blocks("alert.json", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, blocks, []map[string]any{{"type": "divider"}})
+	})
+
+	t.Run("should return an error when the json is invalid", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.json", `[{"type": {{.Title}}}]`)
+
+		_, err := templates.Blocks("alert.json", notification)
+
+		assert.AreEqual(
+			t,
+			strings.HasPrefix(err.Error(), "error unmarshalling blocks of template alert.json:"),
+			true,
+		)
+	})
+
+	t.Run("should render notifications with the renderer", func(t *testing.T) {
+		templates := New()
+		_ = templates.Add("alert.json", `[{"type": "divider"}]`)
+
+		blocks, err := templates.BlocksRenderer("alert.json")(notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, blocks, []map[string]any{{"type": "divider"}})
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should load templates from a file system", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"templates/alert.html.tmpl": {Data: []byte("<h1>{{.Title}}</h1>")},
+			"templates/alert.txt.tmpl":  {Data: []byte("{{.Title}}")},
+		}
+		templates := New()
+
+		err := templates.ParseFS(fsys, "templates/*.tmpl")
+		html, _ := templates.HTML("alert.html.tmpl", notification)
+		text, _ := templates.Text("alert.txt.tmpl", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, html, "<h1>Deploy &lt;failed&gt;</h1>")
+		assert.AreEqual(t, text, "Deploy <failed>")
+	})
+
+	t.Run("should return an error when no template matches", func(t *testing.T) {
+		err := New().ParseFS(fstest.MapFS{}, "*.tmpl")
+
+		assert.AreEqual(t, err.Error(), "no templates match *.tmpl")
+	})
+
+	t.Run("should load templates from files", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "alert.txt")
+		_ = os.WriteFile(filename, []byte("{{.Title}}"), 0o600)
+		templates := New()
+
+		err := templates.ParseFiles(filename)
+		text, _ := templates.Text("alert.txt", notification)
+
+		assert.IsNil(t, err)
+		assert.AreEqual(t, text, "Deploy <failed>")
+	})
+
+	t.Run("should return an error when a file is missing", func(t *testing.T) {
+		err := New().ParseFiles(filepath.Join(t.TempDir(), "missing.txt"))
+
+		assert.AreEqual(t, strings.HasPrefix(err.Error(), "error reading template:"), true)
+	})
+}